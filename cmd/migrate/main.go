@@ -0,0 +1,141 @@
+// Command migrate drives the versioned SQL migrations embedded in
+// internal/adapters/database/migrations against the configured database,
+// independent of the GORM AutoMigrate path main.go falls back to behind
+// DB_AUTO_MIGRATE.
+//
+// Usage:
+//
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down N
+//	go run ./cmd/migrate force V
+//	go run ./cmd/migrate version
+//	go run ./cmd/migrate create NAME
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database/migrations"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/config"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+
+	// create only scaffolds files on disk, so it doesn't need a database
+	// connection or a valid .env.
+	if cmd == "create" {
+		if len(args) != 1 {
+			usage()
+			os.Exit(1)
+		}
+		if err := create(args[0]); err != nil {
+			logger.Fatalf("migrate create: %s", err)
+		}
+		return
+	}
+
+	if err := config.SetupConfig(); err != nil {
+		logger.Fatalf("config SetupConfig() error: %s", err)
+	}
+
+	dsn := migrations.MasterDatabaseURL()
+	if err := database.DbConnection(dsn, dsn); err != nil {
+		logger.Fatalf("database DbConnection error: %s", err)
+	}
+
+	mg, err := migrations.NewMigratorFromDB(database.DB)
+	if err != nil {
+		logger.Fatalf("migrate: %s", err)
+	}
+	defer mg.Close()
+
+	switch cmd {
+	case "up":
+		if err := mg.Up(); err != nil {
+			logger.Fatalf("migrate up: %s", err)
+		}
+		logger.Infof("migrations applied")
+	case "down":
+		if len(args) != 1 {
+			usage()
+			os.Exit(1)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			logger.Fatalf("migrate down: N must be a positive integer")
+		}
+		if err := mg.Down(n); err != nil {
+			logger.Fatalf("migrate down: %s", err)
+		}
+		logger.Infof("rolled back %d migration(s)", n)
+	case "force":
+		if len(args) != 1 {
+			usage()
+			os.Exit(1)
+		}
+		v, err := strconv.Atoi(args[0])
+		if err != nil {
+			logger.Fatalf("migrate force: V must be an integer")
+		}
+		if err := mg.Force(v); err != nil {
+			logger.Fatalf("migrate force: %s", err)
+		}
+		logger.Infof("forced schema version to %d", v)
+	case "version":
+		version, dirty, err := mg.Version()
+		if err != nil {
+			logger.Fatalf("migrate version: %s", err)
+		}
+		fmt.Printf("version=%d dirty=%t\n", version, dirty)
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate <up|down N|force V|version|create NAME>")
+}
+
+// create scaffolds a new pair of up/down SQL files in
+// internal/adapters/database/migrations, numbered one past the highest
+// existing version.
+func create(name string) error {
+	dir := filepath.Join("internal", "adapters", "database", "migrations")
+	matches, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	for _, path := range matches {
+		v, err := strconv.Atoi(strings.SplitN(filepath.Base(path), "_", 2)[0])
+		if err == nil && v >= next {
+			next = v + 1
+		}
+	}
+
+	safeName := strings.ReplaceAll(strings.ToLower(name), " ", "_")
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", next, safeName))
+
+	for _, suffix := range []string{"up", "down"} {
+		path := fmt.Sprintf("%s.%s.sql", base, suffix)
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("-- %s migration: %s\n", suffix, name)), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("created", path)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/spf13/viper"
 	"gorm.io/driver/postgres"
@@ -26,6 +29,9 @@ func DbConnection(masterDSN, replicaDSN string) error {
 		loglevel = gormlogger.Info
 	}
 
+	masterDSN = withTLSParams(masterDSN)
+	replicaDSN = withTLSParams(replicaDSN)
+
 	db, err = gorm.Open(postgres.Open(masterDSN), &gorm.Config{
 		Logger: gormlogger.Default.LogMode(loglevel),
 	})
@@ -45,6 +51,35 @@ func DbConnection(masterDSN, replicaDSN string) error {
 	return nil
 }
 
+// withTLSParams appends the Postgres libpq TLS keywords (sslmode,
+// sslrootcert, sslcert, sslkey) configured via DB_SSLMODE/DB_SSLROOTCERT/
+// DB_SSLCERT/DB_SSLKEY to dsn, so master and replica connections can
+// require verified TLS without every caller having to build that into the
+// DSN it passes to DbConnection.
+//
+// Only the keywords that are actually set are appended; an unset
+// DB_SSLMODE leaves dsn's own sslmode (or libpq's "prefer" default) alone.
+func withTLSParams(dsn string) string {
+	params := map[string]string{
+		"sslmode":     viper.GetString("DB_SSLMODE"),
+		"sslrootcert": viper.GetString("DB_SSLROOTCERT"),
+		"sslcert":     viper.GetString("DB_SSLCERT"),
+		"sslkey":      viper.GetString("DB_SSLKEY"),
+	}
+
+	var extra []string
+	for _, key := range []string{"sslmode", "sslrootcert", "sslcert", "sslkey"} {
+		if value := params[key]; value != "" {
+			extra = append(extra, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	if len(extra) == 0 {
+		return dsn
+	}
+
+	return strings.TrimSpace(dsn) + " " + strings.Join(extra, " ")
+}
+
 // GetDB connection
 func GetDB() *gorm.DB {
 	return DB
@@ -6,16 +6,27 @@ import (
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 )
 
-// Migrate runs database migrations for all models.
+// Migrate runs database migrations for all models using GORM AutoMigrate.
 //
-// Currently uses GORM AutoMigrate for development convenience.
-// For production, consider using golang-migrate or similar versioned migration tool.
+// Dev-only: it can't express column drops/renames or data backfills, and
+// running it against a database also managed by the versioned migrations
+// under this package (see Migrator, EnsureSchemaCurrent, and cmd/migrate)
+// would fight the schema_migrations bookkeeping those track. main.go only
+// calls this when DB_AUTO_MIGRATE=true; otherwise it calls
+// EnsureSchemaCurrent, which expects `go run ./cmd/migrate up` to have been
+// run out of band.
 //
 // Models are migrated in order to handle foreign key dependencies.
 func Migrate() {
 	var migrationModels = []interface{}{
-		&models.User{},    // Users table (for authentication)
-		&models.Example{}, // Example table
+		&models.User{},            // Users table (for authentication)
+		&models.OAuthIdentity{},   // Linked external identity provider accounts
+		&models.RefreshToken{},    // Hashed, rotating refresh tokens
+		&models.Token{},           // Unified store for password reset, email verification, OAuth state, and magic-link tokens
+		&models.MFARecoveryCode{}, // One-time recovery codes for TOTP 2FA
+		&models.Role{},            // Named permission grouping (also doubles as a scope)
+		&models.UserRole{},        // User <-> Role assignments
+		&models.Example{},         // Example table
 	}
 
 	logger.Infof("Starting database migrations...")
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	"gorm.io/gorm"
+)
+
+// Migrator drives the embedded SQL migrations against a database
+// connection, independent of the GORM models AutoMigrate uses.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// NewMigratorFromDB builds a Migrator reusing db's underlying *sql.DB,
+// rather than opening a second connection to the same database.
+func NewMigratorFromDB(db *gorm.DB) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get underlying *sql.DB: %w", err)
+	}
+
+	src, err := Source()
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := migratepostgres.WithInstance(sqlDB, &migratepostgres.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("open postgres driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		return nil, fmt.Errorf("open migrator: %w", err)
+	}
+	return &Migrator{m: m}, nil
+}
+
+// Up applies every pending migration.
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the last n applied migrations.
+func (mg *Migrator) Down(n int) error {
+	if err := mg.m.Steps(-n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force sets the recorded schema version without running its up/down SQL,
+// clearing the dirty flag a failed migration leaves behind.
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Version reports the currently applied migration version and whether the
+// last migration attempt left the schema dirty (partially applied). A
+// database with no migrations applied yet reports version 0, dirty false.
+func (mg *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := mg.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Close releases the underlying source and database resources. For a
+// Migrator built by NewMigratorFromDB this includes db's shared *sql.DB
+// connection pool, since the postgres driver's Close() closes the instance
+// it was handed.
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	if srcErr != nil {
+		return srcErr
+	}
+	return dbErr
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// MasterDatabaseURL builds a GORM-style postgres DSN for the master
+// database from the same MASTER_DB_* keys pkg/config.ValidateConfig
+// requires. cmd/migrate uses it to open its own connection, since it runs
+// standalone rather than through main.go's app wiring.
+func MasterDatabaseURL() string {
+	sslmode := viper.GetString("MASTER_DB_SSLMODE")
+	if sslmode == "" {
+		sslmode = "disable"
+	}
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		viper.GetString("MASTER_DB_HOST"),
+		viper.GetString("MASTER_DB_PORT"),
+		viper.GetString("MASTER_DB_USER"),
+		viper.GetString("MASTER_DB_PASSWORD"),
+		viper.GetString("MASTER_DB_NAME"),
+		sslmode,
+	)
+}
+
+// EnsureSchemaCurrent checks db's applied migration version against
+// LatestVersion, records it as the schema_version metric, and returns an
+// error if the schema is dirty, or if they don't match unless
+// MIGRATIONS_REQUIRE_CURRENT is explicitly set to false (which only logs a
+// warning for a version mismatch; a dirty schema is always an error).
+//
+// Called at startup in place of Migrate/AutoMigrate, so a deployment that
+// forgot to run `go run ./cmd/migrate up` fails fast instead of serving
+// traffic against a stale schema.
+func EnsureSchemaCurrent(db *gorm.DB) error {
+	// Not deferring mg.Close() here: the postgres driver's Close() closes
+	// the *sql.DB it was handed, and this one is the application's shared
+	// connection pool, not a throwaway one.
+	mg, err := NewMigratorFromDB(db)
+	if err != nil {
+		return fmt.Errorf("open migrator: %w", err)
+	}
+
+	version, dirty, err := mg.Version()
+	if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	metrics.SetSchemaVersion(version)
+
+	latest, err := LatestVersion()
+	if err != nil {
+		return fmt.Errorf("read latest embedded version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("database schema is dirty at version %d; run `go run ./cmd/migrate force V` to clear it", version)
+	}
+
+	if version == latest {
+		return nil
+	}
+
+	msg := fmt.Sprintf("database schema is at version %d, expected %d; run `go run ./cmd/migrate up`", version, latest)
+	if viper.IsSet("MIGRATIONS_REQUIRE_CURRENT") && !viper.GetBool("MIGRATIONS_REQUIRE_CURRENT") {
+		logger.Warnf("%s (continuing because MIGRATIONS_REQUIRE_CURRENT=false)", msg)
+		return nil
+	}
+	return fmt.Errorf("%s", msg)
+}
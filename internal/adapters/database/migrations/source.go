@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"regexp"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// sqlFiles embeds every versioned migration so the binary is self-contained
+// and doesn't need this directory on disk at runtime (e.g. in a scratch
+// container image).
+//
+//go:embed *.sql
+var sqlFiles embed.FS
+
+// Source returns a golang-migrate source.Driver reading the embedded SQL
+// files.
+func Source() (source.Driver, error) {
+	return iofs.New(sqlFiles, ".")
+}
+
+// versionPrefix matches the leading "NNNNNN_" version number golang-migrate
+// expects each migration filename to start with.
+var versionPrefix = regexp.MustCompile(`^(\d+)_`)
+
+// LatestVersion returns the highest migration version embedded in the
+// binary, i.e. the version a fully migrated database should be at.
+func LatestVersion() (uint, error) {
+	entries, err := sqlFiles.ReadDir(".")
+	if err != nil {
+		return 0, fmt.Errorf("read embedded migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		m := versionPrefix.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		var v uint
+		if _, err := fmt.Sscanf(m[1], "%d", &v); err != nil {
+			continue
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations embedded")
+	}
+	return latest, nil
+}
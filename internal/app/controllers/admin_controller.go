@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminController handles administrative HTTP requests, gated behind
+// authz.RequireAnyRole("admin") at the router level.
+type AdminController struct {
+	service *services.AdminService
+}
+
+// NewAdminController creates a new AdminController instance.
+func NewAdminController(service *services.AdminService) *AdminController {
+	return &AdminController{service: service}
+}
+
+// UpdateUserRoles replaces the full set of roles held by a user.
+//
+// PATCH /api/admin/users/:id/roles (requires AuthMiddleware + admin role)
+// Request body: UpdateUserRolesRequest (JSON)
+// Response: UserRolesResponse
+func (ctrl *AdminController) UpdateUserRoles(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, err, "Invalid user ID")
+		return
+	}
+
+	var req dto.UpdateUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid update roles request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	response, err := ctrl.service.UpdateUserRoles(uint(userID), req.Roles)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			utils.NotFound(c, err, "User not found")
+			return
+		}
+
+		logger.Errorf("failed to update roles for user %d: %v", userID, err)
+		utils.InternalServerError(c, err, "Failed to update user roles")
+		return
+	}
+
+	utils.Ok(c, response, "User roles updated successfully")
+}
@@ -1,11 +1,8 @@
 package controllers
 
 import (
-	"errors"
-
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
-	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/config"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -39,17 +36,11 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 	}
 
 	// Call service
-	response, err := ctrl.service.Register(&req)
+	reqCtx := services.RequestContext{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+	response, err := ctrl.service.Register(&req, reqCtx)
 	if err != nil {
-		// Handle specific errors
-		if errors.Is(err, services.ErrEmailAlreadyExists) {
-			utils.Conflict(c, err, "Email already exists")
-			return
-		}
-
-		// Handle generic errors
 		logger.Errorf("registration failed: %v", err)
-		utils.InternalServerError(c, err, "Failed to register user")
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -61,7 +52,8 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 //
 // POST /auth/login
 // Request body: LoginRequest (JSON)
-// Response: AuthResponse with user info and JWT token
+// Response: LoginResponse — either an AuthResponse, or (when the account has
+// 2FA enabled) an MFAToken to complete via POST /auth/login/2fa.
 func (ctrl *AuthController) Login(c *gin.Context) {
 	var req dto.LoginRequest
 
@@ -73,17 +65,16 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 	}
 
 	// Call service
-	response, err := ctrl.service.Login(&req)
+	reqCtx := services.RequestContext{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+	response, err := ctrl.service.Login(&req, reqCtx)
 	if err != nil {
-		// Handle specific errors
-		if errors.Is(err, services.ErrInvalidCredentials) {
-			utils.Unauthorized(c, err, "Invalid email or password")
-			return
-		}
-
-		// Handle generic errors
 		logger.Errorf("login failed: %v", err)
-		utils.InternalServerError(c, err, "Failed to authenticate user")
+		utils.RespondError(c, err)
+		return
+	}
+
+	if response.MFARequired {
+		utils.Ok(c, response, "2FA verification required")
 		return
 	}
 
@@ -107,17 +98,11 @@ func (ctrl *AuthController) RefreshToken(c *gin.Context) {
 	}
 
 	// Call service
-	response, err := ctrl.service.RefreshToken(&req)
+	reqCtx := services.RequestContext{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+	response, err := ctrl.service.RefreshToken(&req, reqCtx)
 	if err != nil {
-		// Handle specific errors
-		if errors.Is(err, services.ErrInvalidRefreshToken) {
-			utils.Unauthorized(c, err, "Invalid or expired refresh token")
-			return
-		}
-
-		// Handle generic errors
 		logger.Errorf("token refresh failed: %v", err)
-		utils.InternalServerError(c, err, "Failed to refresh token")
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -129,7 +114,9 @@ func (ctrl *AuthController) RefreshToken(c *gin.Context) {
 //
 // POST /auth/forgot-password
 // Request body: ForgotPasswordRequest (JSON)
-// Response: Success message (token sent via email in production)
+// Response: a generic success message, whether or not the email is
+// registered, so this endpoint can't be used to enumerate accounts. The
+// actual reset link is delivered by email.
 func (ctrl *AuthController) ForgotPassword(c *gin.Context) {
 	var req dto.ForgotPasswordRequest
 
@@ -141,36 +128,14 @@ func (ctrl *AuthController) ForgotPassword(c *gin.Context) {
 	}
 
 	// Call service
-	resetToken, err := ctrl.service.ForgotPassword(&req)
-	if err != nil {
-		// Handle specific errors
-		if errors.Is(err, services.ErrUserNotFound) {
-			// Return success even if user not found (security best practice)
-			// Don't reveal if email exists in system
-			utils.Ok(c, nil, "If the email exists, a password reset link has been sent")
-			return
-		}
-
-		// Handle generic errors
+	if err := ctrl.service.ForgotPassword(&req); err != nil {
 		logger.Errorf("forgot password failed: %v", err)
 		utils.InternalServerError(c, err, "Failed to process request")
 		return
 	}
 
-	// Success response
-	// In production, don't return the token in response; send via email
-	if config.IsProduction() {
-		utils.Ok(c, map[string]string{
-			"message": "Password reset instructions sent to email",
-		}, "Password reset initiated")
-		return
-	}
-
-	// Non-production: include token for development/testing convenience
-	utils.Ok(c, map[string]string{
-		"message": "Password reset instructions sent to email",
-		"token":   resetToken,
-	}, "Password reset initiated")
+	// Success response - identical regardless of whether the email exists
+	utils.Ok(c, nil, "If the email exists, a password reset link has been sent")
 }
 
 // ResetPassword handles password reset endpoint.
@@ -191,22 +156,75 @@ func (ctrl *AuthController) ResetPassword(c *gin.Context) {
 	// Call service
 	err := ctrl.service.ResetPassword(&req)
 	if err != nil {
-		// Handle specific errors
-		if errors.Is(err, services.ErrInvalidResetToken) {
-			utils.BadRequest(c, err, "Invalid reset token")
-			return
-		}
-		if errors.Is(err, services.ErrResetTokenExpired) {
-			utils.BadRequest(c, err, "Reset token has expired")
-			return
-		}
-
-		// Handle generic errors
 		logger.Errorf("password reset failed: %v", err)
-		utils.InternalServerError(c, err, "Failed to reset password")
+		utils.RespondError(c, err)
 		return
 	}
 
 	// Success response
 	utils.Ok(c, nil, "Password reset successfully")
 }
+
+// Logout handles single-session logout by revoking the presented refresh token.
+//
+// POST /auth/logout
+// Request body: RefreshTokenRequest (JSON)
+func (ctrl *AuthController) Logout(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid logout request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	if err := ctrl.service.Logout(&req); err != nil {
+		logger.Errorf("logout failed: %v", err)
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Ok(c, nil, "Logged out successfully")
+}
+
+// LogoutAll revokes every refresh token belonging to the authenticated user.
+//
+// POST /api/logout-all (requires AuthMiddleware)
+func (ctrl *AuthController) LogoutAll(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	if err := ctrl.service.LogoutAll(userID); err != nil {
+		logger.Errorf("logout-all failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to log out of all sessions")
+		return
+	}
+
+	utils.Ok(c, nil, "Logged out of all sessions")
+}
+
+// Introspect reports whether an access token is currently valid, in the
+// RFC 7662 token introspection response shape, so other services holding
+// the token can validate it without sharing this one's signing key.
+//
+// POST /auth/token/introspect
+// Request body: IntrospectRequest (JSON)
+// Response: IntrospectResponse — always 200, even for an invalid/expired
+// token (it comes back as {"active": false} rather than an error).
+func (ctrl *AuthController) Introspect(c *gin.Context) {
+	var req dto.IntrospectRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid introspect request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	response, err := ctrl.service.Introspect(req.Token)
+	if err != nil {
+		logger.Errorf("token introspection failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to introspect token")
+		return
+	}
+
+	utils.Ok(c, response, "")
+}
@@ -1,9 +1,12 @@
 package controllers
 
 import (
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // HealthController handles health check and metrics endpoints.
@@ -23,7 +26,7 @@ func NewHealthController(service *services.HealthService) *HealthController {
 // GET /health
 // Returns health status of the application and its dependencies.
 func (ctrl *HealthController) Health(c *gin.Context) {
-	response := ctrl.service.CheckHealth()
+	response := ctrl.service.CheckHealth(c.Request.Context())
 
 	// Return 503 if unhealthy, 200 if healthy
 	if response.Status == "unhealthy" {
@@ -39,11 +42,57 @@ func (ctrl *HealthController) Health(c *gin.Context) {
 	utils.Ok(c, response, "Service is healthy")
 }
 
-// Metrics returns application metrics.
+// Live performs a liveness check.
+//
+// GET /healthz/live
+// Always reports healthy once the process is up; doesn't look at
+// dependencies, so it never flaps due to a struggling database.
+func (ctrl *HealthController) Live(c *gin.Context) {
+	utils.Ok(c, ctrl.service.CheckLiveness(), "Service is live")
+}
+
+// Ready performs a readiness check.
+//
+// GET /healthz/ready
+// Returns 503 if any critical dependency is unhealthy, so orchestrators
+// can pull the instance out of rotation until it recovers.
+func (ctrl *HealthController) Ready(c *gin.Context) {
+	response := ctrl.service.CheckReadiness(c.Request.Context())
+
+	if response.Status == "unhealthy" {
+		c.JSON(503, gin.H{
+			"success": false,
+			"message": "Service is not ready",
+			"data":    response,
+			"errors":  nil,
+		})
+		return
+	}
+
+	utils.Ok(c, response, "Service is ready")
+}
+
+// Metrics exposes application metrics.
 //
 // GET /metrics
-// Returns basic request counters and uptime statistics.
+// Serves the Prometheus registry via promhttp.HandlerFor, so the endpoint
+// can be scraped directly by Prometheus instead of parsed as a JSON API
+// response. Gated at the route level: by middlewares.RequireMetricsToken at
+// GET /metrics, and by middlewares.MTLSRequired at GET /admin/metrics.
 func (ctrl *HealthController) Metrics(c *gin.Context) {
-	response := ctrl.service.GetMetrics()
-	utils.Ok(c, response, "Metrics retrieved successfully")
+	promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}).ServeHTTP(c.Writer, c.Request)
+}
+
+// MetricsJSON exposes the same request counters as Metrics in JSON, for
+// dashboards/tooling written against the original JSON endpoint before the
+// Prometheus exposition format was added at GET /metrics.
+//
+// GET /metrics/json
+func (ctrl *HealthController) MetricsJSON(c *gin.Context) {
+	utils.Ok(c, &dto.MetricsResponse{
+		TotalRequests:   metrics.GetTotalRequests(),
+		SuccessRequests: metrics.GetSuccessRequests(),
+		ErrorRequests:   metrics.GetErrorRequests(),
+		Uptime:          metrics.GetUptime(),
+	}, "Metrics retrieved successfully")
 }
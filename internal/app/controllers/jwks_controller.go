@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSController exposes the application's public signing keys so that
+// downstream services can validate tokens issued with an asymmetric
+// algorithm without sharing a symmetric secret.
+type JWKSController struct {
+	service *services.AuthService
+}
+
+// NewJWKSController creates a new JWKSController instance.
+func NewJWKSController(service *services.AuthService) *JWKSController {
+	return &JWKSController{service: service}
+}
+
+// JWKS serves the current key set in JSON Web Key Set format.
+//
+// GET /.well-known/jwks.json
+// Returns an empty key set (not an error) when the app is configured with
+// HS256, since there is no public key to publish in that mode.
+func (ctrl *JWKSController) JWKS(c *gin.Context) {
+	jwks, ok := ctrl.service.JWKS()
+	if !ok {
+		c.JSON(http.StatusOK, gin.H{"keys": []interface{}{}})
+		return
+	}
+	c.JSON(http.StatusOK, jwks)
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MFAController handles TOTP two-factor authentication HTTP requests.
+type MFAController struct {
+	service *services.MFAService
+}
+
+// NewMFAController creates a new MFAController instance.
+func NewMFAController(service *services.MFAService) *MFAController {
+	return &MFAController{service: service}
+}
+
+// Enroll starts TOTP enrollment for the authenticated user.
+//
+// POST /api/2fa/enroll (requires AuthMiddleware)
+// Response: MFAEnrollResponse with the otpauth:// URL and a QR code
+func (ctrl *MFAController) Enroll(c *gin.Context) {
+	user, err := ctrl.loadUser(c)
+	if err != nil {
+		return
+	}
+
+	response, err := ctrl.service.Enroll(user)
+	if err != nil {
+		if errors.Is(err, services.ErrMFAAlreadyEnabled) {
+			utils.Conflict(c, err, "2FA is already enabled")
+			return
+		}
+
+		logger.Errorf("2fa enrollment failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to start 2FA enrollment")
+		return
+	}
+
+	utils.Ok(c, response, "Scan the QR code and verify to activate 2FA")
+}
+
+// Verify confirms TOTP enrollment and activates 2FA.
+//
+// POST /api/2fa/verify (requires AuthMiddleware)
+// Request body: MFAVerifyRequest (JSON)
+// Response: MFAVerifyResponse with one-time recovery codes
+func (ctrl *MFAController) Verify(c *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid 2fa verify request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	user, err := ctrl.loadUser(c)
+	if err != nil {
+		return
+	}
+
+	response, err := ctrl.service.Verify(user, req.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrMFANoPendingSetup) {
+			utils.BadRequest(c, err, "No pending 2FA enrollment; call /2fa/enroll first")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidMFACode) {
+			utils.Unauthorized(c, err, "Invalid or expired 2FA code")
+			return
+		}
+
+		logger.Errorf("2fa verification failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to verify 2FA code")
+		return
+	}
+
+	utils.Ok(c, response, "2FA enabled successfully")
+}
+
+// Disable turns off 2FA for the authenticated user.
+//
+// POST /api/2fa/disable (requires AuthMiddleware)
+// Request body: MFADisableRequest (JSON)
+func (ctrl *MFAController) Disable(c *gin.Context) {
+	var req dto.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid 2fa disable request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	user, err := ctrl.loadUser(c)
+	if err != nil {
+		return
+	}
+
+	if err := ctrl.service.Disable(user, req.Code); err != nil {
+		if errors.Is(err, services.ErrMFANotEnabled) {
+			utils.BadRequest(c, err, "2FA is not enabled")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidMFACode) {
+			utils.Unauthorized(c, err, "Invalid or expired 2FA code")
+			return
+		}
+
+		logger.Errorf("2fa disable failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to disable 2FA")
+		return
+	}
+
+	utils.Ok(c, nil, "2FA disabled successfully")
+}
+
+// Login2FA completes a login that was paused for 2FA verification.
+//
+// POST /auth/login/2fa
+// Request body: MFALoginRequest (JSON)
+// Response: AuthResponse with user info and JWT tokens
+func (ctrl *MFAController) Login2FA(c *gin.Context) {
+	var req dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("invalid 2fa login request: %v", err)
+		utils.BadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	reqCtx := services.RequestContext{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+	response, err := ctrl.service.VerifyLoginChallenge(&req, reqCtx)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMFACode) {
+			utils.Unauthorized(c, err, "Invalid or expired 2FA code")
+			return
+		}
+
+		logger.Errorf("2fa login failed: %v", err)
+		utils.InternalServerError(c, err, "Failed to complete 2FA login")
+		return
+	}
+
+	utils.Ok(c, response, "Login successful")
+}
+
+// loadUser fetches the authenticated user's record, writing an error
+// response and returning a non-nil error if it can't.
+func (ctrl *MFAController) loadUser(c *gin.Context) (*models.User, error) {
+	userID := c.GetUint("user_id")
+
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		logger.Errorf("failed to load user %d: %v", userID, err)
+		utils.InternalServerError(c, err, "Failed to load user")
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/config"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// oauthStateCookie is the name of the short-lived cookie used to protect the
+// OAuth authorization flow against CSRF/state-fixation.
+const oauthStateCookie = "oauth_state"
+
+// oauthVerifierCookie holds the PKCE code verifier generated at the start
+// of the flow, so Callback can replay it to the token endpoint alongside
+// the authorization code.
+const oauthVerifierCookie = "oauth_pkce_verifier"
+
+// oauthNonceCookie holds the OIDC nonce generated at the start of the
+// flow, so Callback can check it against an id_token's "nonce" claim.
+const oauthNonceCookie = "oauth_nonce"
+
+// OAuthController handles the "login with an external identity provider"
+// endpoints.
+type OAuthController struct {
+	service *services.OAuthService
+}
+
+// NewOAuthController creates a new OAuthController instance.
+func NewOAuthController(service *services.OAuthService) *OAuthController {
+	return &OAuthController{
+		service: service,
+	}
+}
+
+// Login redirects the client to the provider's authorization page.
+//
+// GET /auth/oauth/:provider/login
+// Sets a short-lived HttpOnly state cookie that Callback compares with the
+// returned state query parameter before exchanging the code.
+func (ctrl *OAuthController) Login(c *gin.Context) {
+	provider := c.Param("provider")
+
+	state, err := ctrl.service.GenerateState()
+	if err != nil {
+		logger.Errorf("failed to generate oauth state: %v", err)
+		utils.InternalServerError(c, err, "Failed to start OAuth login")
+		return
+	}
+
+	codeVerifier, err := ctrl.service.GenerateCodeVerifier()
+	if err != nil {
+		logger.Errorf("failed to generate oauth pkce verifier: %v", err)
+		utils.InternalServerError(c, err, "Failed to start OAuth login")
+		return
+	}
+
+	nonce, err := ctrl.service.GenerateNonce()
+	if err != nil {
+		logger.Errorf("failed to generate oauth nonce: %v", err)
+		utils.InternalServerError(c, err, "Failed to start OAuth login")
+		return
+	}
+
+	authURL, err := ctrl.service.AuthCodeURL(provider, state, codeVerifier, nonce)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownOAuthProvider) {
+			utils.BadRequest(c, err, "Unsupported or unconfigured OAuth provider")
+			return
+		}
+		logger.Errorf("failed to build oauth auth url: %v", err)
+		utils.InternalServerError(c, err, "Failed to start OAuth login")
+		return
+	}
+
+	secure := config.CookieSecure()
+	c.SetCookie(oauthStateCookie, state, 300, "/", "", secure, true)
+	c.SetCookie(oauthVerifierCookie, codeVerifier, 300, "/", "", secure, true)
+	c.SetCookie(oauthNonceCookie, nonce, 300, "/", "", secure, true)
+	c.Redirect(http.StatusTemporaryRedirect, authURL)
+}
+
+// Callback exchanges the authorization code and issues the standard
+// AuthResponse (access + refresh token) on success.
+//
+// GET /auth/oauth/:provider/callback
+func (ctrl *OAuthController) Callback(c *gin.Context) {
+	provider := c.Param("provider")
+	code := c.Query("code")
+
+	if code == "" {
+		utils.BadRequest(c, nil, "Missing authorization code")
+		return
+	}
+
+	secure := config.CookieSecure()
+
+	expectedState, err := c.Cookie(oauthStateCookie)
+	if err != nil || expectedState == "" || expectedState != c.Query("state") {
+		logger.Warnf("oauth callback state mismatch for provider %s", provider)
+		utils.Unauthorized(c, nil, "Invalid or expired OAuth state")
+		return
+	}
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", secure, true)
+
+	codeVerifier, err := c.Cookie(oauthVerifierCookie)
+	if err != nil || codeVerifier == "" {
+		logger.Warnf("oauth callback missing pkce verifier for provider %s", provider)
+		utils.Unauthorized(c, nil, "Invalid or expired OAuth state")
+		return
+	}
+	c.SetCookie(oauthVerifierCookie, "", -1, "/", "", secure, true)
+
+	// The nonce cookie may be absent for a flow started before this cookie
+	// existed; HandleCallback skips the nonce check when it's empty rather
+	// than failing the login outright.
+	nonce, _ := c.Cookie(oauthNonceCookie)
+	c.SetCookie(oauthNonceCookie, "", -1, "/", "", secure, true)
+
+	reqCtx := services.RequestContext{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+	response, err := ctrl.service.HandleCallback(c.Request.Context(), provider, code, codeVerifier, nonce, reqCtx)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownOAuthProvider) {
+			utils.BadRequest(c, err, "Unsupported or unconfigured OAuth provider")
+			return
+		}
+		if errors.Is(err, services.ErrOAuthEmailMissing) {
+			utils.BadRequest(c, err, "Provider account has no verified email address")
+			return
+		}
+		logger.Errorf("oauth callback failed for provider %s: %v", provider, err)
+		utils.InternalServerError(c, err, "Failed to complete OAuth login")
+		return
+	}
+
+	utils.Ok(c, response, "Login successful")
+}
+
+// LoginOIDC is the fixed-provider equivalent of Login for clients that
+// expect a dedicated OIDC endpoint rather than the generic :provider one.
+//
+// GET /auth/oidc/login
+func (ctrl *OAuthController) LoginOIDC(c *gin.Context) {
+	c.Params = append(c.Params, gin.Param{Key: "provider", Value: "oidc"})
+	ctrl.Login(c)
+}
+
+// CallbackOIDC is the fixed-provider equivalent of Callback.
+//
+// GET /auth/oidc/callback
+func (ctrl *OAuthController) CallbackOIDC(c *gin.Context) {
+	c.Params = append(c.Params, gin.Param{Key: "provider", Value: "oidc"})
+	ctrl.Callback(c)
+}
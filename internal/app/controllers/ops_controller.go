@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database/migrations"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OpsController handles the internal operator endpoints (migration status,
+// rate-limiter introspection) mounted on the mTLS-gated admin router group,
+// for internal scrapers rather than end users.
+type OpsController struct{}
+
+// NewOpsController creates a new OpsController instance.
+func NewOpsController() *OpsController {
+	return &OpsController{}
+}
+
+// MigrationStatus reports the database's applied golang-migrate version
+// against the version embedded in the binary.
+//
+// GET /admin/migrations/status (requires middlewares.MTLSRequired)
+func (ctrl *OpsController) MigrationStatus(c *gin.Context) {
+	// Not deferring mg.Close() here, same as migrations.EnsureSchemaCurrent:
+	// the postgres driver's Close() would close database.DB's shared
+	// connection pool, not just this throwaway driver wrapper.
+	mg, err := migrations.NewMigratorFromDB(database.DB)
+	if err != nil {
+		utils.InternalServerError(c, err, "Failed to open migrator")
+		return
+	}
+
+	version, dirty, err := mg.Version()
+	if err != nil {
+		utils.InternalServerError(c, err, "Failed to read schema version")
+		return
+	}
+
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		utils.InternalServerError(c, err, "Failed to read latest embedded migration version")
+		return
+	}
+
+	utils.Ok(c, &dto.MigrationStatusResponse{
+		Version: version,
+		Latest:  latest,
+		Dirty:   dirty,
+		Current: !dirty && version == latest,
+	}, "Migration status retrieved successfully")
+}
+
+// RateLimiterStats reports the in-process IP rate limiter's current state.
+//
+// GET /admin/rate-limiter (requires middlewares.MTLSRequired)
+func (ctrl *OpsController) RateLimiterStats(c *gin.Context) {
+	stats := middlewares.RateLimiterSnapshot()
+	utils.Ok(c, &dto.RateLimiterStatsResponse{
+		TrackedIPs: stats.TrackedIPs,
+		RPS:        stats.RPS,
+		Burst:      stats.Burst,
+	}, "Rate limiter stats retrieved successfully")
+}
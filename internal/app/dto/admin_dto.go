@@ -0,0 +1,16 @@
+package dto
+
+// UpdateUserRolesRequest replaces the full set of roles held by a user.
+//
+// This is also the grant/revoke endpoint for scopes: a role's name doubles
+// as a scope (see models.Role), so there is no separate scope-grant
+// mechanism or models.User.Scopes field alongside it.
+type UpdateUserRolesRequest struct {
+	Roles []string `json:"roles" binding:"required"`
+}
+
+// UserRolesResponse reports the roles currently assigned to a user.
+type UserRolesResponse struct {
+	UserID uint     `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
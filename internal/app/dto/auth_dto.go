@@ -10,8 +10,9 @@ type RegisterRequest struct {
 	// Email must be unique and valid format
 	Email string `json:"email" binding:"required,email"`
 
-	// Password must be at least 8 characters
-	Password string `json:"password" binding:"required,min=8"`
+	// Password must satisfy the configured password policy (see
+	// services.SetupPasswordPolicy), enforced by the "strongpassword" tag.
+	Password string `json:"password" binding:"required,min=8,strongpassword"`
 }
 
 // LoginRequest represents the payload for user authentication.
@@ -38,6 +39,11 @@ type AuthResponse struct {
 
 	// TokenType is always "Bearer" for JWT
 	TokenType string `json:"token_type"`
+
+	// GrantedScopes lists the scopes/roles embedded in AccessToken (see the
+	// "scope" claim and pkg/authz.RequireScope), so a client can decide
+	// which UI to show without decoding the token itself.
+	GrantedScopes []string `json:"granted_scopes"`
 }
 
 // UserResponse represents user information in API responses.
@@ -78,6 +84,31 @@ type ResetPasswordRequest struct {
 	// Token is the password reset token sent via email
 	Token string `json:"token" binding:"required"`
 
-	// NewPassword is the new password to set (min 8 characters)
-	NewPassword string `json:"new_password" binding:"required,min=8"`
+	// NewPassword must satisfy the configured password policy (see
+	// services.SetupPasswordPolicy), enforced by the "strongpassword" tag.
+	NewPassword string `json:"new_password" binding:"required,min=8,strongpassword"`
+}
+
+// IntrospectRequest is the payload for POST /auth/token/introspect.
+type IntrospectRequest struct {
+	// Token is the access token to introspect.
+	Token string `json:"token" binding:"required"`
+}
+
+// IntrospectResponse mirrors the RFC 7662 token introspection response
+// shape. Active is the only field guaranteed to be set; every other field
+// is omitted when Active is false, per the RFC.
+type IntrospectResponse struct {
+	Active bool `json:"active"`
+
+	// Scope is the space-delimited "scope" claim (see pkg/authz.Scope).
+	Scope     string `json:"scope,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Aud       string `json:"aud,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	JTI       string `json:"jti,omitempty"`
 }
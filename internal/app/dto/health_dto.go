@@ -19,22 +19,18 @@ type HealthResponse struct {
 	Uptime int64 `json:"uptime_seconds,omitempty"`
 }
 
-// MetricsResponse represents basic application metrics.
-//
-// Returns request counters and basic statistics.
+// MetricsResponse is the backward-compatible JSON view of the request
+// counters also exposed in Prometheus text format at GET /metrics.
 type MetricsResponse struct {
-	// TotalRequests is the total number of requests handled
+	// TotalRequests is the number of HTTP requests recorded so far.
 	TotalRequests int64 `json:"total_requests"`
 
-	// SuccessRequests is the number of successful requests (2xx, 3xx)
+	// SuccessRequests is the number of recorded requests with a 2xx/3xx status.
 	SuccessRequests int64 `json:"success_requests"`
 
-	// ErrorRequests is the number of failed requests (4xx, 5xx)
+	// ErrorRequests is the number of recorded requests with a 4xx/5xx status.
 	ErrorRequests int64 `json:"error_requests"`
 
-	// UptimeSeconds is time since application started
-	UptimeSeconds int64 `json:"uptime_seconds"`
-
-	// Timestamp of when metrics were collected
-	Timestamp time.Time `json:"timestamp"`
+	// Uptime in seconds since application started
+	Uptime int64 `json:"uptime_seconds"`
 }
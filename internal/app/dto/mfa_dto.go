@@ -0,0 +1,53 @@
+package dto
+
+// MFAEnrollResponse is returned when a user starts TOTP enrollment.
+//
+// The secret is not yet active; it becomes the user's permanent TOTP secret
+// only once MFAVerifyRequest confirms a valid code.
+type MFAEnrollResponse struct {
+	// Secret is the base32 TOTP secret, shown as a fallback for manual entry.
+	Secret string `json:"secret"`
+
+	// OTPAuthURL is the otpauth:// URI encoded in the QR code.
+	OTPAuthURL string `json:"otpauth_url"`
+
+	// QRCodePNGBase64 is a base64-encoded PNG of the QR code for OTPAuthURL.
+	QRCodePNGBase64 string `json:"qr_code_png_base64"`
+}
+
+// MFAVerifyRequest confirms TOTP enrollment with a live 6-digit code.
+type MFAVerifyRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// MFAVerifyResponse returns one-time recovery codes after 2FA is activated.
+//
+// Each code is shown to the user exactly once; only its bcrypt hash is stored.
+type MFAVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFADisableRequest confirms disabling 2FA with a live TOTP code.
+type MFADisableRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// LoginResponse is returned by POST /auth/login.
+//
+// When the account has 2FA enabled, Auth is omitted and MFARequired/MFAToken
+// are set instead; the client must call POST /auth/login/2fa with MFAToken
+// and a TOTP or recovery code to receive the actual AuthResponse.
+type LoginResponse struct {
+	MFARequired bool          `json:"mfa_required"`
+	MFAToken    string        `json:"mfa_token,omitempty"`
+	Auth        *AuthResponse `json:"auth,omitempty"`
+}
+
+// MFALoginRequest completes a login that was paused for 2FA verification.
+type MFALoginRequest struct {
+	// MFAToken is the short-lived token returned by LoginResponse.
+	MFAToken string `json:"mfa_token" binding:"required"`
+
+	// Code is either a 6-digit TOTP code or an unused recovery code.
+	Code string `json:"code" binding:"required"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// MigrationStatusResponse reports the database's currently applied
+// golang-migrate version against the version embedded in the binary.
+type MigrationStatusResponse struct {
+	Version uint `json:"version"`
+	Latest  uint `json:"latest"`
+	Dirty   bool `json:"dirty"`
+	Current bool `json:"current"`
+}
+
+// RateLimiterStatsResponse reports the in-process IP rate limiter's
+// current state (see middlewares.RateLimiterSnapshot).
+type RateLimiterStatsResponse struct {
+	TrackedIPs int     `json:"tracked_ips"`
+	RPS        float64 `json:"rps"`
+	Burst      int     `json:"burst"`
+}
@@ -12,7 +12,8 @@ import (
 // AuthMiddleware validates JWT token and protects routes.
 //
 // Expects Authorization header with format: "Bearer <token>"
-// On success, sets "user_id" in gin.Context
+// On success, sets "user_id", "roles", "scopes" and "scope" (see
+// pkg/authz.RequireScope) in gin.Context
 // On failure, returns 401 Unauthorized
 func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -37,7 +38,7 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		token := parts[1]
 
 		// Validate token
-		userID, err := authService.ValidateToken(token)
+		claims, err := authService.ValidateAccessToken(token)
 		if err != nil {
 			logger.Warnf("invalid token: %v", err)
 			utils.Unauthorized(ctx, err, "Invalid or expired token")
@@ -45,8 +46,11 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		// Set user ID in context for downstream handlers
-		ctx.Set("user_id", userID)
+		// Set identity and authorization data in context for downstream handlers
+		ctx.Set("user_id", claims.UserID)
+		ctx.Set("roles", claims.Roles)
+		ctx.Set("scopes", claims.Scopes)
+		ctx.Set("scope", claims.Scope)
 
 		// Continue to next handler
 		ctx.Next()
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware negotiates the caller's language and stashes it on the
+// gin.Context via i18n.WithContext, for pkg/utils's response helpers and
+// FormatValidationErrors to translate against for the rest of the request.
+//
+// The language is taken from, in priority order, the "?lang=" query
+// parameter (an explicit override, e.g. for a logged-in user's saved
+// preference) and then the Accept-Language header, negotiated against
+// whichever locales bundle loaded.
+func LocaleMiddleware(bundle *i18n.Bundle) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accept := c.Query("lang")
+		if accept == "" {
+			accept = c.GetHeader("Accept-Language")
+		}
+
+		i18n.WithContext(c, bundle, bundle.Match(accept))
+		c.Next()
+	}
+}
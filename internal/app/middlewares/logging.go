@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLoggingMiddleware emits one structured log line per request,
+// replacing Gin's default logger.
+//
+// Attaches request_id, trace_id, method and path to the request's context
+// via logger.WithContext, so handlers and anything they call can log with
+// the same correlation fields via logger.Ctx(ctx) without threading them
+// through call signatures. Expects RequestIDMiddleware to run first so
+// request_id is already set.
+func RequestLoggingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		fields := logger.Fields{
+			"request_id": c.GetString("request_id"),
+			"trace_id":   c.GetHeader("X-Trace-ID"),
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+		}
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), fields))
+
+		c.Next()
+
+		// user_id is only known once AuthMiddleware has run, so it's added
+		// to the context's fields after c.Next() rather than before.
+		if userID, ok := c.Get("user_id"); ok {
+			fields["user_id"] = userID
+		}
+
+		logger.FromContext(logger.WithContext(c.Request.Context(), fields)).WithFields(map[string]interface{}{
+			"status":     c.Writer.Status(),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"size_bytes": c.Writer.Size(),
+			"client_ip":  c.ClientIP(),
+		}).Infof("%s %s", c.Request.Method, c.Request.URL.Path)
+	}
+}
@@ -1,26 +1,43 @@
 package middlewares
 
 import (
+	"time"
+
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
 	"github.com/gin-gonic/gin"
 )
 
-// MetricsMiddleware records request metrics.
+// MetricsMiddleware records per-route request counts and latency.
 //
-// Records:
-// - Total request count
-// - Success count (2xx, 3xx status codes)
-// - Error count (4xx, 5xx status codes)
+// Records, keyed by HTTP method and matched route:
+// - http_requests_total, broken down by status code
+// - http_request_duration_seconds, as a latency histogram
+// - http_requests_in_flight, incremented/decremented around the handler
 //
-// Metrics are stored in memory and can be retrieved via /metrics endpoint.
-// Uses atomic operations for thread-safety with minimal overhead.
+// Uses c.FullPath() rather than the raw request path so a parameterized
+// route like "/users/:id" aggregates into one series instead of one per id.
+// Skips instrumenting GET /metrics itself, so scraping Prometheus doesn't
+// show up as a recurring request in its own series.
 func MetricsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Process request
+		if c.FullPath() == "/metrics" {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		stopInFlight := metrics.StartInFlight()
+		defer stopInFlight()
+
 		c.Next()
 
-		// Record metrics after request completes
-		statusCode := c.Writer.Status()
-		metrics.RecordRequest(statusCode)
+		route := c.FullPath()
+		if route == "" {
+			// No route matched (e.g. a 404), fall back to a fixed label so
+			// unmatched paths don't blow up series cardinality.
+			route = "unmatched"
+		}
+
+		metrics.RecordRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
 	}
 }
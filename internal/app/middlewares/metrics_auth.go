@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// RequireMetricsToken gates GET /metrics behind a bearer token when
+// METRICS_TOKEN is configured.
+//
+// If METRICS_TOKEN is unset, the endpoint is left open, matching the
+// existing deployments that scrape it from inside a trusted network.
+func RequireMetricsToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := viper.GetString("METRICS_TOKEN")
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		if c.GetHeader("Authorization") != "Bearer "+token {
+			utils.Unauthorized(c, nil, "Invalid or missing metrics bearer token")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MTLSRequired builds a middleware that 403s any request that didn't
+// present a client certificate whose CommonName or one of its DNS SANs is
+// in allowedCNs. It only checks identity, not trust: verifying the
+// certificate chain itself is the listener's job (see
+// pkg/tlsconfig.LoadServerTLS with authType "verify").
+//
+// An empty allowedCNs accepts any certificate that made it past the TLS
+// handshake, useful when the listener's ClientCAs already scopes who can
+// connect at all.
+func MTLSRequired(allowedCNs ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(allowedCNs))
+	for _, cn := range allowedCNs {
+		allowed[cn] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+			utils.Forbidden(c, errors.New("no client certificate presented"), "Client certificate required")
+			c.Abort()
+			return
+		}
+
+		if len(allowed) == 0 {
+			c.Next()
+			return
+		}
+
+		cert := c.Request.TLS.PeerCertificates[0]
+		if _, ok := allowed[cert.Subject.CommonName]; ok {
+			c.Next()
+			return
+		}
+		for _, name := range cert.DNSNames {
+			if _, ok := allowed[name]; ok {
+				c.Next()
+				return
+			}
+		}
+
+		utils.Forbidden(c, errors.New("client certificate not authorized"), "Client certificate not authorized")
+		c.Abort()
+	}
+}
@@ -1,10 +1,14 @@
 package middlewares
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
@@ -42,6 +46,14 @@ func (i *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
 	return limiter
 }
 
+// RateLimiterStats summarizes the global in-process IP rate limiter's
+// current state, for admin introspection (see RateLimiterSnapshot).
+type RateLimiterStats struct {
+	TrackedIPs int     `json:"tracked_ips"`
+	RPS        float64 `json:"rps"`
+	Burst      int     `json:"burst"`
+}
+
 // CleanupOldLimiters removes limiters that haven't been used recently
 // Call this periodically to prevent memory leaks
 func (i *IPRateLimiter) CleanupOldLimiters() {
@@ -81,6 +93,25 @@ func initRateLimiter() {
 	})
 }
 
+// RateLimiterSnapshot reports how many IPs the global rate limiter used by
+// RateLimitMiddleware is currently tracking and the limit it enforces.
+//
+// It only reflects that one middleware's state, not every
+// RateLimitMiddlewareWithPolicy call site, since each policy can bring its
+// own RateLimitStore.
+func RateLimiterSnapshot() RateLimiterStats {
+	initRateLimiter()
+
+	globalLimiter.mu.RLock()
+	defer globalLimiter.mu.RUnlock()
+
+	return RateLimiterStats{
+		TrackedIPs: len(globalLimiter.ips),
+		RPS:        float64(globalLimiter.r),
+		Burst:      globalLimiter.b,
+	}
+}
+
 // RateLimitMiddleware creates a rate limiting middleware
 //
 // Limits requests per IP address to prevent abuse and DDoS attacks.
@@ -109,6 +140,110 @@ func RateLimitMiddleware() gin.HandlerFunc {
 	}
 }
 
+// RateLimitPolicy configures RateLimitMiddlewareWithPolicy.
+//
+// Key extracts the identity to rate-limit on from the request — by IP, by
+// authenticated user ID, by API key, or by route group, allowing different
+// traffic (e.g. /auth/login vs. general API traffic) to be limited
+// independently and by a different dimension than client IP.
+type RateLimitPolicy struct {
+	Key   func(c *gin.Context) string
+	RPS   int
+	Burst int
+	Store RateLimitStore
+}
+
+// ByIP keys a RateLimitPolicy on the client IP address.
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUserID keys a RateLimitPolicy on the authenticated user ID set by
+// AuthMiddleware (a uint, via ctx.Set("user_id", claims.UserID)), falling
+// back to the client IP for unauthenticated requests so the policy still
+// applies to them.
+func ByUserID(c *gin.Context) string {
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(uint); ok && id != 0 {
+			return fmt.Sprintf("user:%d", id)
+		}
+	}
+	return ByIP(c)
+}
+
+// ByAPIKey keys a RateLimitPolicy on the caller's X-API-Key header, falling
+// back to the client IP when the header is absent.
+func ByAPIKey(c *gin.Context) string {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return "apikey:" + apiKey
+	}
+	return ByIP(c)
+}
+
+// ByRoute keys a RateLimitPolicy on the matched route, regardless of caller
+// identity — useful for an endpoint-wide cap (e.g. a total budget on
+// /auth/login across all callers) rather than a per-caller one.
+func ByRoute(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	return "route:" + route
+}
+
+// Composite keys a RateLimitPolicy on the concatenation of every given key
+// function's result, e.g. Composite(ByRoute, ByUserID) to cap each user
+// independently per route rather than across the whole API.
+func Composite(keys ...func(c *gin.Context) string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key(c)
+		}
+		return strings.Join(parts, "+")
+	}
+}
+
+// RateLimitMiddlewareWithPolicy creates a rate limiting middleware driven by
+// a RateLimitPolicy, so the same middleware can back a per-IP global limit,
+// a per-user limit applied after authentication, or a per-route limit on a
+// sensitive endpoint like /auth/login, depending on Policy.Key and
+// Policy.Store.
+//
+// Unlike RateLimitMiddlewareWithConfig, it reports the IETF-draft
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers on every
+// response, plus Retry-After when denied, and records every decision via
+// metrics.RecordRateLimitDecision.
+func RateLimitMiddlewareWithPolicy(policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := policy.Key(c)
+
+		allowed, remaining, resetAfter, err := policy.Store.Allow(c.Request.Context(), key, policy.RPS, policy.Burst)
+		if err != nil {
+			logger.Errorf("rate limit store error for key %s: %v", key, err)
+			c.Next()
+			return
+		}
+
+		metrics.RecordRateLimitDecision(allowed)
+
+		resetSeconds := int(resetAfter.Seconds() + 1)
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.RPS))
+		c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(resetSeconds))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(resetSeconds))
+			logger.Warnf("rate limit exceeded for key: %s", key)
+			utils.TooManyRequests(c, nil, "Rate limit exceeded. Please try again later.")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // RateLimitMiddlewareWithConfig creates a rate limiting middleware with custom config
 //
 // Parameters:
@@ -0,0 +1,141 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitStore decides whether a request identified by key is allowed
+// under a requests-per-second/burst policy. Implementations may track
+// state in-process (fine for a single replica) or in a shared backend like
+// Redis (required once the service runs multiple replicas behind a load
+// balancer, since an in-process map can't see another replica's requests).
+type RateLimitStore interface {
+	// Allow reports whether a request for key is allowed under rps/burst,
+	// how many requests remain in the current window, and how long until
+	// that window resets (also the minimum wait before retrying, when denied).
+	Allow(ctx context.Context, key string, rps, burst int) (allowed bool, remaining int, resetAfter time.Duration, err error)
+}
+
+// memoryStore is the pre-existing in-process token-bucket behavior
+// (see IPRateLimiter), reshaped to satisfy RateLimitStore so it can be
+// swapped for a Redis-backed one without changing middleware call sites.
+type memoryStore struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewMemoryRateLimitStore creates an in-process RateLimitStore. Breaks down
+// when the service runs multiple replicas, since each replica keeps its own
+// limiter state; use NewRedisRateLimitStore for that case.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryStore{limiters: make(map[string]*rate.Limiter)}
+}
+
+func (s *memoryStore) Allow(_ context.Context, key string, rps, burst int) (bool, int, time.Duration, error) {
+	s.mu.Lock()
+	limiter, ok := s.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		s.limiters[key] = limiter
+	}
+	s.mu.Unlock()
+
+	// How long until one more token regenerates; used as the Reset hint on
+	// an allowed request, since a token bucket has no fixed window boundary.
+	refill := time.Second
+	if rps > 0 {
+		refill = time.Duration(float64(time.Second) / float64(rps))
+	}
+
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		return false, 0, refill, nil
+	}
+
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, remainingTokens(limiter), delay, nil
+	}
+
+	return true, remainingTokens(limiter), refill, nil
+}
+
+func remainingTokens(limiter *rate.Limiter) int {
+	if tokens := int(limiter.Tokens()); tokens > 0 {
+		return tokens
+	}
+	return 0
+}
+
+// redisStore is a distributed fixed-window counter backed by Redis, safe to
+// share across every replica of the service.
+//
+// Each key is bucketed into fixed windows of size `window`; the effective
+// request budget per window is rps*window (rounded), or burst if that is
+// larger, and never less than 1. The increment-and-set-TTL step
+// runs as a single Lua script (incrAndExpireScript) so a replica can never
+// observe a key that was INCRed but not yet given an expiry by a concurrent
+// request, which a separate INCR+PEXPIRE pair could race on.
+type redisStore struct {
+	client *redis.Client
+	window time.Duration
+}
+
+// NewRedisRateLimitStore creates a Redis-backed RateLimitStore, bucketing
+// requests into fixed windows of the given size (e.g. one second).
+func NewRedisRateLimitStore(client *redis.Client, window time.Duration) RateLimitStore {
+	if window <= 0 {
+		window = time.Second
+	}
+	return &redisStore{client: client, window: window}
+}
+
+// incrAndExpireScript atomically increments the window counter and, only
+// the first time it's created, sets its expiry to the window size — doing
+// both in one round trip so the key can never be left without a TTL if the
+// process crashed between an INCR and a PEXPIRE.
+var incrAndExpireScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+func (s *redisStore) Allow(ctx context.Context, key string, rps, burst int) (bool, int, time.Duration, error) {
+	windowSeconds := s.window.Seconds()
+	windowIndex := time.Now().UnixNano() / s.window.Nanoseconds()
+	windowKey := fmt.Sprintf("rl:%s:%d", key, windowIndex)
+	nextWindow := time.Unix(0, (windowIndex+1)*s.window.Nanoseconds())
+
+	limit := int(math.Round(float64(rps) * windowSeconds))
+	if burst > limit {
+		limit = burst
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+
+	count, err := incrAndExpireScript.Run(ctx, s.client, []string{windowKey}, s.window.Milliseconds()).Int64()
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("redis rate limit store: %w", err)
+	}
+
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if int(count) > limit {
+		return false, remaining, time.Until(nextWindow), nil
+	}
+
+	return true, remaining, time.Until(nextWindow), nil
+}
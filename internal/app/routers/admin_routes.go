@@ -0,0 +1,51 @@
+package routers
+
+import (
+	"strings"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/controllers"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// RegisterAdminRoutes registers the internal operator surface: metrics,
+// migration status, and rate-limiter introspection.
+//
+// Unlike RegisterHealthRoutes, it's gated behind mutual TLS (see
+// middlewares.MTLSRequired) rather than a bearer token, so it's meant to be
+// served on its own listener/port (see main.go's admin listener) reachable
+// only by internal scrapers presenting a client certificate.
+func RegisterAdminRoutes(router *gin.Engine) {
+	healthService := services.NewHealthService()
+	healthController := controllers.NewHealthController(healthService)
+	opsController := controllers.NewOpsController()
+
+	admin := router.Group("/admin")
+	admin.Use(middlewares.MTLSRequired(allowedAdminCNs()...))
+	{
+		admin.GET("/metrics", healthController.Metrics)
+		admin.GET("/migrations/status", opsController.MigrationStatus)
+		admin.GET("/rate-limiter", opsController.RateLimiterStats)
+	}
+}
+
+// allowedAdminCNs reads the comma-separated client certificate
+// CommonName/SAN allowlist for the admin surface from ADMIN_MTLS_ALLOWED_CNS.
+// Unset (the default) accepts any certificate that passed the listener's
+// TLS handshake, relying on its ClientCAs to scope who can connect at all.
+func allowedAdminCNs() []string {
+	raw := viper.GetString("ADMIN_MTLS_ALLOWED_CNS")
+	if raw == "" {
+		return nil
+	}
+
+	var cns []string
+	for _, cn := range strings.Split(raw, ",") {
+		if cn = strings.TrimSpace(cn); cn != "" {
+			cns = append(cns, cn)
+		}
+	}
+	return cns
+}
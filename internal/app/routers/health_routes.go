@@ -2,8 +2,10 @@ package routers
 
 import (
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/controllers"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 )
 
 // RegisterHealthRoutes registers health check and metrics endpoints.
@@ -20,5 +22,21 @@ func RegisterHealthRoutes(router *gin.Engine) {
 
 	// Health check routes (no middleware needed)
 	router.GET("/health", healthController.Health)
-	router.GET("/metrics", healthController.Metrics)
+
+	// Kubernetes-style liveness/readiness probes.
+	router.GET("/healthz/live", healthController.Live)
+	router.GET("/healthz/ready", healthController.Ready)
+
+	// The Prometheus exposition endpoint can be turned off entirely (e.g. a
+	// deployment that scrapes metrics some other way), defaulting to on.
+	// It's gated behind an optional bearer token (see
+	// middlewares.RequireMetricsToken) when enabled, rather than being fully
+	// public.
+	if !viper.IsSet("METRICS_PROMETHEUS_ENABLED") || viper.GetBool("METRICS_PROMETHEUS_ENABLED") {
+		router.GET("/metrics", middlewares.RequireMetricsToken(), healthController.Metrics)
+	}
+
+	// Kept for tooling/dashboards written against the original JSON metrics
+	// response, now that /metrics serves the Prometheus exposition format.
+	router.GET("/metrics/json", healthController.MetricsJSON)
 }
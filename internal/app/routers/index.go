@@ -2,15 +2,32 @@ package routers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/controllers"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/authz"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/config"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 )
 
 // RegisterRoutes adds all routing list here automatically get main router
 func RegisterRoutes(route *gin.Engine) {
+	// Tag every request with a correlation ID and log it as one structured
+	// line, replacing Gin's default logger.
+	route.Use(middlewares.RequestIDMiddleware())
+	route.Use(middlewares.RequestLoggingMiddleware())
+
+	// Negotiate the caller's language (?lang= or Accept-Language) so
+	// response messages and validation errors translate for the rest of
+	// the request. Skipped entirely if SetupI18n found no locales to load.
+	if services.LocaleBundle != nil {
+		route.Use(middlewares.LocaleMiddleware(services.LocaleBundle))
+	}
+
 	route.NoRoute(func(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Route Not Found"})
 	})
@@ -21,17 +38,53 @@ func RegisterRoutes(route *gin.Engine) {
 
 	// Initialize services
 	authService := services.NewAuthService()
+	oauthService := services.NewOAuthService(authService)
+	mfaService := services.NewMFAService(authService)
+	adminService := services.NewAdminService()
 
 	// Initialize controllers
 	authController := controllers.NewAuthController(authService)
+	oauthController := controllers.NewOAuthController(oauthService)
+	jwksController := controllers.NewJWKSController(authService)
+	mfaController := controllers.NewMFAController(mfaService)
+	adminController := controllers.NewAdminController(adminService)
+
+	// Publishes the current public signing key(s) when JWT_ALG is RS256/ES256
+	route.GET("/.well-known/jwks.json", jwksController.JWKS)
 
 	// Auth routes (public - no authentication required)
 	// Apply rate limiting to prevent brute force attacks
 	authRoutes := route.Group("/auth")
-	authRoutes.Use(middlewares.RateLimitMiddleware())
+	authRoutes.Use(middlewares.RateLimitMiddlewareWithPolicy(middlewares.RateLimitPolicy{
+		Key:   middlewares.ByIP,
+		RPS:   config.RateLimitRPS(),
+		Burst: config.RateLimitBurst(),
+		Store: newRateLimitStore(),
+	}))
 	{
 		authRoutes.POST("/register", authController.Register)
 		authRoutes.POST("/login", authController.Login)
+		authRoutes.POST("/refresh", authController.RefreshToken)
+		authRoutes.POST("/logout", authController.Logout)
+
+		// RFC 7662 token introspection, for other services that hold an
+		// access token but not this one's signing key
+		authRoutes.POST("/token/introspect", authController.Introspect)
+
+		// Completes a login that was paused for 2FA verification
+		authRoutes.POST("/login/2fa", mfaController.Login2FA)
+
+		// OAuth2/OIDC login via an external identity provider (google, github, oidc)
+		oauthRoutes := authRoutes.Group("/oauth/:provider")
+		{
+			oauthRoutes.GET("/login", oauthController.Login)
+			oauthRoutes.GET("/callback", oauthController.Callback)
+		}
+
+		// Dedicated OIDC endpoints, equivalent to /auth/oauth/oidc/..., for
+		// clients that expect a fixed-provider OIDC login URL.
+		authRoutes.GET("/oidc/login", oauthController.LoginOIDC)
+		authRoutes.GET("/oidc/callback", oauthController.CallbackOIDC)
 	}
 
 	// Protected routes (require authentication)
@@ -50,6 +103,24 @@ func RegisterRoutes(route *gin.Engine) {
 			})
 		})
 
+		// Revoke every refresh token for the authenticated user (log out of all devices)
+		protectedRoutes.POST("/logout-all", authController.LogoutAll)
+
+		// TOTP two-factor authentication management
+		mfaRoutes := protectedRoutes.Group("/2fa")
+		{
+			mfaRoutes.POST("/enroll", mfaController.Enroll)
+			mfaRoutes.POST("/verify", mfaController.Verify)
+			mfaRoutes.POST("/disable", mfaController.Disable)
+		}
+
+		// Admin-only user management
+		adminRoutes := protectedRoutes.Group("/admin")
+		adminRoutes.Use(authz.RequireAnyRole("admin"))
+		{
+			adminRoutes.PATCH("/users/:id/roles", adminController.UpdateUserRoles)
+		}
+
 		// Add more protected routes here
 		// protectedRoutes.GET("/users", controllers.GetUsers)
 		// protectedRoutes.POST("/users", controllers.CreateUser)
@@ -58,3 +129,20 @@ func RegisterRoutes(route *gin.Engine) {
 	// Add All route
 	// TestRoutes(route)
 }
+
+// newRateLimitStore picks the middlewares.RateLimitStore backend selected
+// by config.RateLimitBackend: "redis" once the service runs multiple
+// replicas behind a load balancer, the in-process "memory" store otherwise.
+func newRateLimitStore() middlewares.RateLimitStore {
+	if config.RateLimitBackend() != "redis" {
+		return middlewares.NewMemoryRateLimitStore()
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.RedisAddr(),
+		Password: config.RedisPassword(),
+		DB:       config.RedisDB(),
+	})
+	logger.Infof("rate limiting backed by redis at %s", config.RedisAddr())
+	return middlewares.NewRedisRateLimitStore(client, time.Second)
+}
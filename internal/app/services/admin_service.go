@@ -0,0 +1,31 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+)
+
+// AdminService handles administrative operations gated behind the "admin" role.
+type AdminService struct{}
+
+// NewAdminService creates a new AdminService instance.
+func NewAdminService() *AdminService {
+	return &AdminService{}
+}
+
+// UpdateUserRoles replaces the full set of roles held by a user.
+//
+// Returns repositories.ErrUserNotFound if userID doesn't exist.
+func (s *AdminService) UpdateUserRoles(userID uint, roles []string) (*dto.UserRolesResponse, error) {
+	if _, err := repositories.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := repositories.ReplaceUserRoles(userID, roles); err != nil {
+		return nil, fmt.Errorf("failed to update user roles: %w", err)
+	}
+
+	return &dto.UserRolesResponse{UserID: userID, Roles: roles}, nil
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/secrets"
+	"github.com/spf13/viper"
+)
+
+// hsSecretName is the secrets.SecretProvider key the HS256 signing secret
+// is stored under, mirroring the JWT_SECRET env var it replaces.
+const hsSecretName = "JWT_SECRET"
+
+// hsKey is a single HS256 signing secret, identified by a kid the same way
+// keys.KeyPair identifies an asymmetric one.
+type hsKey struct {
+	Kid        string
+	Secret     []byte
+	VerifyOnly bool
+}
+
+// hsKeySet holds every HS256 secret still valid for verification, plus
+// which one signs new tokens. When Watch delivers a new secret, the
+// previous active key is demoted to verify-only rather than dropped
+// immediately, so tokens issued moments before a rotation still validate
+// until they expire or the grace period does, whichever is first.
+type hsKeySet struct {
+	mu        sync.RWMutex
+	keys      map[string]*hsKey
+	activeKid string
+}
+
+// newHSKeySet seeds a key set with a single active secret.
+func newHSKeySet(secret []byte) *hsKeySet {
+	ks := &hsKeySet{keys: make(map[string]*hsKey)}
+	kid := hsKid(secret)
+	ks.keys[kid] = &hsKey{Kid: kid, Secret: secret}
+	ks.activeKid = kid
+	return ks
+}
+
+// Active returns the key currently used to sign new tokens.
+func (ks *hsKeySet) Active() (*hsKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	kp, ok := ks.keys[ks.activeKid]
+	if !ok {
+		return nil, fmt.Errorf("no active HS256 signing key loaded")
+	}
+	return kp, nil
+}
+
+// Get returns the key with the given kid, active or verify-only.
+func (ks *hsKeySet) Get(kid string) (*hsKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	kp, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown HS256 signing key kid=%s", kid)
+	}
+	return kp, nil
+}
+
+// Rotate adds secret as the new active signing key, demoting the previous
+// active key to verify-only, and schedules its removal after grace so
+// tokens it signed stop validating once they've had time to expire
+// naturally.
+func (ks *hsKeySet) Rotate(secret []byte, grace time.Duration) {
+	kid := hsKid(secret)
+
+	ks.mu.Lock()
+	previousKid := ks.activeKid
+	if previous, ok := ks.keys[previousKid]; ok {
+		previous.VerifyOnly = true
+	}
+	ks.keys[kid] = &hsKey{Kid: kid, Secret: secret}
+	ks.activeKid = kid
+	ks.mu.Unlock()
+
+	logger.Infof("rotated HS256 signing key, new kid=%s, previous kid=%s demoted to verify-only", kid, previousKid)
+
+	if previousKid == "" || previousKid == kid {
+		return
+	}
+	time.AfterFunc(grace, func() {
+		ks.mu.Lock()
+		delete(ks.keys, previousKid)
+		ks.mu.Unlock()
+		logger.Infof("retired HS256 signing key kid=%s after grace period", previousKid)
+	})
+}
+
+// hsKid derives a short, stable key ID from a secret's SHA-256, the same
+// way keys.loadKeyFile derives a kid from an asymmetric public key.
+func hsKid(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// hsKeyGraceTTL is how long a demoted signing key remains valid for
+// verification after a rotation, configurable via JWT_KEY_GRACE_PERIOD_HOURS
+// (default 24h, covering the access token's own 24h lifetime).
+func hsKeyGraceTTL() time.Duration {
+	hours := viper.GetInt("JWT_KEY_GRACE_PERIOD_HOURS")
+	if hours <= 0 {
+		hours = 24
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// hsKeySetSingleton lazily initializes the package-level HS256 key set from
+// an EnvProvider, mirroring jwtKeyRegistry's lazy setup of asymmetric keys.
+// This is the fallback path for apps that never call SetupSecretProvider
+// (e.g. existing deployments, or tests): it reproduces the exact original
+// "read JWT_SECRET from viper" behavior.
+var (
+	hsKeys     *hsKeySet
+	hsKeysOnce sync.Once
+	hsKeysErr  error
+)
+
+func hsKeySetSingleton() (*hsKeySet, error) {
+	hsKeysOnce.Do(func() {
+		secret, err := secrets.NewEnvProvider().GetSecret(context.Background(), hsSecretName)
+		if err != nil {
+			hsKeysErr = err
+			return
+		}
+		hsKeys = newHSKeySet(secret)
+	})
+	return hsKeys, hsKeysErr
+}
+
+// SetupSecretProvider builds the SecretProvider configured via
+// SECRET_PROVIDER ("env" (default), "vault", or "aws"), seeds the HS256
+// signing key set from it, and starts watching for rotations. Call this
+// once at startup, before anything signs or verifies an HS256 token; it
+// fails fast so a misconfigured secret backend doesn't surface as cryptic
+// token errors later.
+//
+// A non-HS256 JWT_ALG (see jwtAlg/jwtKeyRegistry) doesn't use this at all,
+// since asymmetric keys come from JWT_PRIVATE_KEY_PATH instead.
+func SetupSecretProvider() error {
+	if jwtAlg() != "HS256" {
+		return nil
+	}
+
+	provider, err := newSecretProvider()
+	if err != nil {
+		return fmt.Errorf("failed to initialize secret provider: %w", err)
+	}
+
+	secret, err := provider.GetSecret(context.Background(), hsSecretName)
+	if err != nil {
+		return fmt.Errorf("failed to load initial %s: %w", hsSecretName, err)
+	}
+
+	hsKeysOnce.Do(func() {
+		hsKeys = newHSKeySet(secret)
+	})
+
+	grace := hsKeyGraceTTL()
+	go func() {
+		for newSecret := range provider.Watch(hsSecretName) {
+			hsKeys.Rotate(newSecret, grace)
+		}
+	}()
+
+	return nil
+}
+
+// newSecretProvider builds a secrets.SecretProvider from config.
+func newSecretProvider() (secrets.SecretProvider, error) {
+	switch backend := viper.GetString("SECRET_PROVIDER"); backend {
+	case "", "env":
+		return secrets.NewEnvProvider(), nil
+	case "vault":
+		return secrets.NewVaultProvider(secrets.VaultConfig{
+			Addr:     viper.GetString("VAULT_ADDR"),
+			Token:    viper.GetString("VAULT_TOKEN"),
+			RoleID:   viper.GetString("VAULT_ROLE_ID"),
+			SecretID: viper.GetString("VAULT_SECRET_ID"),
+			Mount:    viper.GetString("VAULT_KV_MOUNT"),
+			Path:     viper.GetString("VAULT_SECRET_PATH"),
+		}), nil
+	case "aws":
+		return secrets.NewAWSSecretsManagerProvider(context.Background(), secrets.AWSSecretsManagerConfig{
+			Region:   viper.GetString("AWS_REGION"),
+			SecretID: viper.GetString("AWS_SECRETS_MANAGER_SECRET_ID"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown SECRET_PROVIDER %q", backend)
+	}
+}
@@ -1,46 +1,74 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/apierr"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/authz"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/keys"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/mailer"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/password"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/viper"
-	"golang.org/x/crypto/bcrypt"
 )
 
-// Common errors for auth service
+// Common errors for auth service.
+//
+// These are declared as *apierr.Problem rather than plain errors.New
+// values so AuthController can render them as RFC 7807 Problem Details via
+// utils.RespondError without a per-error branch; errors.Is/errors.As
+// against them (including through fmt.Errorf("%w: ...", ...) wrapping)
+// behave exactly as they would for any other package-level sentinel error.
 var (
-	ErrEmailAlreadyExists      = errors.New("email already exists")
-	ErrInvalidCredentials      = errors.New("invalid email or password")
-	ErrUserNotFound            = errors.New("user not found")
-	ErrInvalidRefreshToken     = errors.New("invalid or expired refresh token")
-	ErrInvalidResetToken       = errors.New("invalid or expired reset token")
-	ErrResetTokenExpired       = errors.New("reset token has expired")
+	ErrEmailAlreadyExists  = apierr.New("auth.email_exists", http.StatusConflict, "Email Already Exists")
+	ErrInvalidCredentials  = apierr.New("auth.invalid_credentials", http.StatusUnauthorized, "Invalid Credentials")
+	ErrInvalidRefreshToken = apierr.New("auth.invalid_refresh_token", http.StatusUnauthorized, "Invalid Refresh Token")
+	ErrInvalidResetToken   = apierr.New("auth.invalid_reset_token", http.StatusBadRequest, "Invalid Reset Token")
+	ErrResetTokenExpired   = apierr.New("auth.reset_token_expired", http.StatusBadRequest, "Reset Token Expired")
 )
 
+// RequestContext carries request metadata that gets attached to an issued
+// refresh token (user agent, IP) so a "log out of all devices" screen could
+// later show which sessions are active. Callers build this from the gin
+// context; the service layer stays free of any HTTP dependency.
+type RequestContext struct {
+	UserAgent string
+	IP        string
+}
+
 // AuthService handles authentication-related business logic
 type AuthService struct {
-	// Dependencies can be added here if needed
+	// backends are the credential sources Login tries, in order; see
+	// NewAuthBackends (auth_backend.go) and the AUTH_BACKENDS config value.
+	backends []AuthBackend
 }
 
 // NewAuthService creates a new AuthService instance
 func NewAuthService() *AuthService {
-	return &AuthService{}
+	s := &AuthService{}
+	s.backends = NewAuthBackends(s)
+	return s
 }
 
 // Register creates a new user account with validation and password hashing.
 //
 // Returns ErrEmailAlreadyExists if email is already registered.
 // Password is hashed using bcrypt before storage.
-func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+func (s *AuthService) Register(req *dto.RegisterRequest, reqCtx RequestContext) (*dto.AuthResponse, error) {
 	// Check if email already exists
 	existingUser, err := repositories.GetUserByEmail(req.Email)
 	if err != nil {
@@ -74,185 +102,571 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 
 	logger.Infof("user registered successfully: %s", user.Email)
 
-	// Generate JWT access token
-	accessToken, err := s.generateToken(user)
-	if err != nil {
-		logger.Errorf("failed to generate access token: %v", err)
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
+	s.bootstrapAdminRole(user)
 
-	// Generate refresh token
-	refreshToken, err := s.generateRefreshToken()
-	if err != nil {
-		logger.Errorf("failed to generate refresh token: %v", err)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
-	}
+	return s.IssueTokens(user, reqCtx, nil)
+}
 
-	// Save refresh token to database
-	user.RefreshToken = refreshToken
-	if err := repositories.UpdateUser(user); err != nil {
-		logger.Errorf("failed to save refresh token: %v", err)
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+// adminRoleName is the role authz.RequireAnyRole checks for on admin-only
+// endpoints.
+const adminRoleName = "admin"
+
+// bootstrapAdminRole grants the "admin" role to a newly registered user when
+// they are either the very first account in the system or their email
+// matches ADMIN_EMAIL, so a fresh deployment always has at least one admin
+// without requiring a manual database edit.
+//
+// Failures are logged, not returned: granting the admin role is a
+// convenience on top of registration, not something that should turn a
+// successful signup into a 500.
+func (s *AuthService) bootstrapAdminRole(user *models.User) {
+	isFirstUser := false
+	if count, err := repositories.CountUsers(); err != nil {
+		logger.Errorf("failed to count users for admin bootstrap: %v", err)
+	} else {
+		isFirstUser = count == 1
 	}
 
-	// Build response
-	response := &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
+	isConfiguredAdmin := viper.GetString("ADMIN_EMAIL") != "" &&
+		strings.EqualFold(viper.GetString("ADMIN_EMAIL"), user.Email)
+
+	if !isFirstUser && !isConfiguredAdmin {
+		return
 	}
 
-	return response, nil
+	if err := repositories.AssignRole(user.ID, adminRoleName); err != nil {
+		logger.Errorf("failed to bootstrap admin role for %s: %v", user.Email, err)
+		return
+	}
+	logger.Infof("granted admin role to %s", user.Email)
 }
 
 // Login authenticates a user with email and password.
 //
 // Returns ErrInvalidCredentials if email or password is incorrect.
-func (s *AuthService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
-	// Get user by email
-	user, err := repositories.GetUserByEmail(req.Email)
+func (s *AuthService) Login(req *dto.LoginRequest, reqCtx RequestContext) (*dto.LoginResponse, error) {
+	user, err := s.authenticate(context.Background(), Credentials{Identifier: req.Email, Secret: req.Password})
 	if err != nil {
-		logger.Errorf("failed to get user: %v", err)
-		return nil, fmt.Errorf("authentication failed: %w", err)
+		return nil, err
 	}
 
-	if user == nil {
-		logger.Warnf("login attempt with non-existent email: %s", req.Email)
-		return nil, ErrInvalidCredentials
+	// A 2FA-enabled account doesn't get tokens yet: it gets a short-lived
+	// mfa_pending token that only POST /auth/login/2fa can redeem.
+	if user.MFAEnabled {
+		logger.Infof("password verified for %s, awaiting 2FA", user.Email)
+		mfaToken, err := s.IssueMFAPendingToken(user)
+		if err != nil {
+			logger.Errorf("failed to issue mfa pending token: %v", err)
+			return nil, fmt.Errorf("failed to start 2FA challenge: %w", err)
+		}
+		return &dto.LoginResponse{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	// Verify password
-	if err = s.verifyPassword(user.Password, req.Password); err != nil {
-		logger.Warnf("login attempt with invalid password: %s", req.Email)
-		return nil, ErrInvalidCredentials
+	logger.Infof("user logged in successfully: %s", user.Email)
+
+	auth, err := s.IssueTokens(user, reqCtx, nil)
+	if err != nil {
+		return nil, err
 	}
+	return &dto.LoginResponse{Auth: auth}, nil
+}
 
-	logger.Infof("user logged in successfully: %s", user.Email)
+// authenticate tries each configured AuthBackend in order (see
+// NewAuthBackends) and resolves the first success to a local models.User,
+// auto-provisioning one if this is the account's first login through a
+// non-local backend.
+//
+// A backend reporting ErrInvalidCredentials just means "these credentials
+// aren't valid for this backend"; authenticate moves on to the next one.
+// Any other error is treated as a hard failure of that backend and returned
+// immediately, since it likely means the backend itself is misconfigured
+// (e.g. an unreadable htpasswd file) rather than a bad login attempt.
+func (s *AuthService) authenticate(ctx context.Context, creds Credentials) (*models.User, error) {
+	for _, backend := range s.backends {
+		info, err := backend.Authenticate(ctx, creds)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				continue
+			}
+			logger.Errorf("%s auth backend failed: %v", backend.Name(), err)
+			return nil, err
+		}
 
-	// Generate JWT access token
-	accessToken, err := s.generateToken(user)
+		user, err := s.resolveBackendUser(backend.Name(), info)
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	logger.Warnf("login attempt rejected by all configured auth backends: %s", creds.Identifier)
+	return nil, ErrInvalidCredentials
+}
+
+// ValidateToken validates a JWT token and returns the user ID.
+//
+// Returns error if token is invalid, expired, or malformed.
+func (s *AuthService) ValidateToken(tokenString string) (uint, error) {
+	claims, err := s.ValidateAccessToken(tokenString)
 	if err != nil {
-		logger.Errorf("failed to generate access token: %v", err)
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return 0, err
 	}
+	return claims.UserID, nil
+}
 
-	// Generate refresh token
-	refreshToken, err := s.generateRefreshToken()
+// TokenClaims is the decoded, typed form of an access token's claims, used
+// by AuthMiddleware to attach identity and authorization data to the
+// request context.
+type TokenClaims struct {
+	UserID uint
+	Roles  []string
+	Scopes []string
+	Scope  authz.Scope
+}
+
+// ValidateAccessToken validates a JWT access token and returns its claims.
+//
+// Returns error if the token is invalid, expired, malformed, or is an
+// mfa_pending token rather than a full access token.
+func (s *AuthService) ValidateAccessToken(tokenString string) (*TokenClaims, error) {
+	claims, err := s.parseClaims(tokenString)
 	if err != nil {
-		logger.Errorf("failed to generate refresh token: %v", err)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
-	// Save refresh token to database
-	user.RefreshToken = refreshToken
-	if err := repositories.UpdateUser(user); err != nil {
-		logger.Errorf("failed to save refresh token: %v", err)
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	// An mfa_pending token is not a full access token; reject it here so a
+	// client can't skip the second factor by reusing it against protected routes.
+	if mfa, _ := claims["mfa"].(bool); mfa {
+		return nil, errors.New("token requires MFA verification")
 	}
 
-	// Build response
-	response := &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("invalid user_id in token")
 	}
 
-	return response, nil
+	scopeClaim, _ := claims["scope"].(string)
+
+	return &TokenClaims{
+		UserID: uint(userID),
+		Roles:  stringSliceClaim(claims, "roles"),
+		Scopes: stringSliceClaim(claims, "scopes"),
+		Scope:  authz.Scope(scopeClaim),
+	}, nil
 }
 
-// ValidateToken validates a JWT token and returns the user ID.
+// Introspect reports on an access token's validity in the RFC 7662 token
+// introspection response shape, so another service holding the token can
+// check it without sharing this one's signing key.
 //
-// Returns error if token is invalid, expired, or malformed.
-func (s *AuthService) ValidateToken(tokenString string) (uint, error) {
-	// Get JWT secret from config
-	secret := viper.GetString("JWT_SECRET")
-	if secret == "" {
-		return 0, errors.New("JWT secret not configured")
+// A token that fails to parse, is expired, or is an mfa_pending token
+// (rather than a full access token) yields {Active: false} and a nil
+// error, per RFC 7662 ("if the introspection call is properly authorized
+// but the token is not active, ... the authorization server MUST NOT
+// ... return an error"); err is only non-nil for an infrastructure failure.
+func (s *AuthService) Introspect(tokenString string) (*dto.IntrospectResponse, error) {
+	claims, err := s.parseClaims(tokenString)
+	if err != nil {
+		return &dto.IntrospectResponse{Active: false}, nil
+	}
+
+	if mfa, _ := claims["mfa"].(bool); mfa {
+		return &dto.IntrospectResponse{Active: false}, nil
 	}
 
-	// Parse token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return &dto.IntrospectResponse{Active: false}, nil
+	}
+
+	scope, _ := claims["scope"].(string)
+	email, _ := claims["email"].(string)
+	iss, _ := claims["iss"].(string)
+	aud, _ := claims["aud"].(string)
+	jti, _ := claims["jti"].(string)
+
+	resp := &dto.IntrospectResponse{
+		Active:    true,
+		Scope:     scope,
+		Username:  email,
+		TokenType: "Bearer",
+		Sub:       fmt.Sprintf("%d", uint(userID)),
+		Iss:       iss,
+		Aud:       aud,
+		JTI:       jti,
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		resp.Exp = int64(exp)
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		resp.Iat = int64(iat)
+	}
+
+	return resp, nil
+}
+
+// stringSliceClaim reads a JWT claim that was encoded as a []string back out
+// of the generic jwt.MapClaims, where it round-trips as []interface{}.
+// Missing or malformed claims yield an empty slice rather than an error,
+// since roles/scopes are additive authorization data, not required for the
+// token to otherwise be valid.
+func stringSliceClaim(claims jwt.MapClaims, key string) []string {
+	raw, ok := claims[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
 		}
-		return []byte(secret), nil
+	}
+	return values
+}
+
+// Private helper methods
+
+// hashPassword hashes a plain text password using the configured Hasher
+// (see passwordHasher), producing a self-describing PHC-formatted hash.
+func (s *AuthService) hashPassword(plainPassword string) (string, error) {
+	hasher, err := passwordHasher()
+	if err != nil {
+		return "", fmt.Errorf("failed to configure password hasher: %w", err)
+	}
+	return hasher.Hash(plainPassword)
+}
+
+// verifyPassword checks a plain text password against a stored PHC-formatted
+// hash, dispatching to whichever algorithm produced it.
+//
+// Returns a non-nil error whenever the password doesn't match, so callers
+// can treat any error as "invalid credentials" without inspecting it.
+func (s *AuthService) verifyPassword(hashedPassword, plainPassword string) error {
+	hasher, err := passwordHasher()
+	if err != nil {
+		return fmt.Errorf("failed to configure password hasher: %w", err)
+	}
+
+	ok, err := hasher.Verify(hashedPassword, plainPassword)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("password does not match")
+	}
+	return nil
+}
+
+// rehashPasswordIfNeeded transparently upgrades a user's stored password
+// hash to the currently configured algorithm/parameters after a successful
+// login, so a deployment can migrate from bcrypt to Argon2id (or raise its
+// Argon2id cost parameters) without forcing every user to reset their
+// password.
+//
+// Failures are logged, not returned: this is a maintenance side-effect of a
+// successful login, not something that should turn it into an error.
+func (s *AuthService) rehashPasswordIfNeeded(user *models.User, plainPassword string) {
+	hasher, err := passwordHasher()
+	if err != nil {
+		return
+	}
+
+	if !hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	newHash, err := hasher.Hash(plainPassword)
+	if err != nil {
+		logger.Errorf("failed to rehash password for %s: %v", user.Email, err)
+		return
+	}
+
+	user.Password = newHash
+	if err := repositories.UpdateUser(user); err != nil {
+		logger.Errorf("failed to persist rehashed password for %s: %v", user.Email, err)
+		return
+	}
+
+	logger.Infof("upgraded password hash for %s", user.Email)
+}
+
+// passwordHasher and passwordHasherOnce lazily configure the password
+// Hasher the first time a password needs to be hashed or verified,
+// mirroring jwtKeyRegistry's lazy setup of signing keys.
+var (
+	pwHasher     *password.Hasher
+	pwHasherOnce sync.Once
+	pwHasherErr  error
+)
+
+func passwordHasher() (*password.Hasher, error) {
+	pwHasherOnce.Do(func() {
+		pwHasher, pwHasherErr = password.New(password.Config{
+			Algo:              viper.GetString("PASSWORD_HASH_ALGO"),
+			Pepper:            viper.GetString("SECRET"),
+			Argon2MemoryKiB:   uint32(viper.GetInt("ARGON2_MEMORY_KIB")),
+			Argon2Iterations:  uint32(viper.GetInt("ARGON2_ITERATIONS")),
+			Argon2Parallelism: uint8(viper.GetInt("ARGON2_PARALLELISM")),
+		})
 	})
+	return pwHasher, pwHasherErr
+}
+
+// SetupPasswordPolicy reads the configured password policy from config and
+// registers it as the "strongpassword" validator tag, so
+// RegisterRequest.Password and ResetPasswordRequest.NewPassword enforce it
+// automatically. Intended to be called once at startup, e.g. from main.
+func SetupPasswordPolicy() error {
+	return password.RegisterStrongPasswordValidator(password.PolicyConfig{
+		MinLength:        viper.GetInt("PASSWORD_MIN_LENGTH"),
+		RequireUppercase: viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+		RequireLowercase: viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+		RequireDigit:     viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+		RequireSymbol:    viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+	})
+}
+
+// mfaPendingTTL is how long a client has to complete the 2FA challenge
+// before having to log in again with their password.
+const mfaPendingTTL = 5 * time.Minute
+
+// IssueMFAPendingToken creates a short-lived token that only proves the
+// caller knows the account's password; it cannot be used to call protected
+// routes (see ValidateToken) until exchanged via ValidateMFAPendingToken.
+func (s *AuthService) IssueMFAPendingToken(user *models.User) (string, error) {
+	now := time.Now()
+	return s.signClaims(jwt.MapClaims{
+		"user_id": user.ID,
+		"mfa":     true,
+		"iat":     now.Unix(),
+		"exp":     now.Add(mfaPendingTTL).Unix(),
+	})
+}
 
+// ValidateMFAPendingToken verifies an mfa_pending token and returns the user
+// ID it was issued for.
+func (s *AuthService) ValidateMFAPendingToken(tokenString string) (uint, error) {
+	claims, err := s.parseClaims(tokenString)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse token: %w", err)
+		return 0, err
 	}
 
-	// Extract claims
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		// Get user ID from claims
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			return 0, errors.New("invalid user_id in token")
-		}
-		return uint(userID), nil
+	if mfa, _ := claims["mfa"].(bool); !mfa {
+		return 0, errors.New("token is not an mfa pending token")
 	}
 
-	return 0, errors.New("invalid token")
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, errors.New("invalid user_id in token")
+	}
+	return uint(userID), nil
 }
 
-// Private helper methods
+// JWKS returns the current public key set for GET /.well-known/jwks.json.
+//
+// The second return value is false when the app is configured for HS256,
+// since there is no public key material to expose in that mode.
+func (s *AuthService) JWKS() (map[string]interface{}, bool) {
+	if jwtAlg() == "HS256" {
+		return nil, false
+	}
 
-// hashPassword hashes a plain text password using bcrypt.
-func (s *AuthService) hashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	registry, err := jwtKeyRegistry()
 	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %w", err)
+		logger.Errorf("failed to load signing keys for jwks: %v", err)
+		return nil, false
 	}
-	return string(hashedBytes), nil
+
+	return registry.JWKS(), true
 }
 
-// verifyPassword compares a hashed password with a plain text password.
-func (s *AuthService) verifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+// jwtAlg returns the configured JWT signing algorithm, defaulting to the
+// original HS256 behavior when JWT_ALG is unset.
+func jwtAlg() string {
+	alg := viper.GetString("JWT_ALG")
+	if alg == "" {
+		return "HS256"
+	}
+	return alg
+}
+
+// jwtKeyRegistry lazily loads the asymmetric signing keys from
+// JWT_PRIVATE_KEY_PATH the first time an RS256/ES256 token is signed or
+// validated.
+var (
+	keyRegistry     *keys.Registry
+	keyRegistryOnce sync.Once
+	keyRegistryErr  error
+)
+
+func jwtKeyRegistry() (*keys.Registry, error) {
+	keyRegistryOnce.Do(func() {
+		dir := viper.GetString("JWT_PRIVATE_KEY_PATH")
+		if dir == "" {
+			keyRegistryErr = errors.New("JWT_PRIVATE_KEY_PATH not configured")
+			return
+		}
+		keyRegistry, keyRegistryErr = keys.NewRegistry(dir)
+	})
+	return keyRegistry, keyRegistryErr
 }
 
 // generateToken creates a JWT token for authenticated user.
 //
-// Token contains user ID and email in claims.
-// Expiry time is 24 hours from creation.
+// Token contains user ID and email in claims, plus the standard OIDC fields
+// (iss, aud, sub, jti) so the token can be consumed as an identity token by
+// other services. Expiry time is 24 hours from creation.
+//
+// Uses HS256 with JWT_SECRET by default; set JWT_ALG=RS256 or ES256 and
+// JWT_PRIVATE_KEY_PATH to sign with an asymmetric key instead, publishing the
+// public half at GET /.well-known/jwks.json.
 func (s *AuthService) generateToken(user *models.User) (string, error) {
-	// Get JWT secret from config
-	secret := viper.GetString("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT secret not configured")
+	now := time.Now()
+	jti, err := s.generateRefreshToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate jti: %w", err)
+	}
+
+	roles, err := repositories.GetUserRoleNames(user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load user roles: %w", err)
 	}
 
-	// Create claims
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
 		"email":   user.Email,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // 24 hours expiry
-		"iat":     time.Now().Unix(),
+		"sub":     fmt.Sprintf("%d", user.ID),
+		"jti":     jti,
+		"exp":     now.Add(time.Hour * 24).Unix(), // 24 hours expiry
+		"iat":     now.Unix(),
+		// Scopes mirror role names 1:1: this codebase has no separate
+		// scope-grant mechanism, so a role such as "recipes:write" both
+		// authorizes authz.RequireAnyRole("recipes:write") and
+		// authz.RequireScope("recipes:write"). "scope" is the same grant set
+		// again, space-delimited per RFC 7662, for authz.RequireScope and
+		// POST /auth/token/introspect.
+		"roles":  roles,
+		"scopes": roles,
+		"scope":  authz.New(roles...).String(),
+	}
+	if iss := viper.GetString("JWT_ISSUER"); iss != "" {
+		claims["iss"] = iss
 	}
+	if aud := viper.GetString("JWT_AUDIENCE"); aud != "" {
+		claims["aud"] = aud
+	}
+
+	return s.signClaims(claims)
+}
+
+// signClaims signs an arbitrary claim set with whichever algorithm/key the
+// app is configured with, shared by generateToken and any other short-lived
+// token the auth flow needs to issue (e.g. an MFA pending token).
+func (s *AuthService) signClaims(claims jwt.MapClaims) (string, error) {
+	alg := jwtAlg()
+	if alg == "HS256" {
+		keySet, err := hsKeySetSingleton()
+		if err != nil {
+			return "", fmt.Errorf("failed to load HS256 signing key: %w", err)
+		}
+		kp, err := keySet.Active()
+		if err != nil {
+			return "", err
+		}
 
-	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = kp.Kid
+		return token.SignedString(kp.Secret)
+	}
 
-	// Sign token with secret
-	tokenString, err := token.SignedString([]byte(secret))
+	registry, err := jwtKeyRegistry()
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return "", fmt.Errorf("failed to load signing keys: %w", err)
+	}
+	kp, err := registry.Active()
+	if err != nil {
+		return "", fmt.Errorf("failed to select signing key: %w", err)
 	}
 
+	var method jwt.SigningMethod
+	switch kp.Alg {
+	case "RS256":
+		method = jwt.SigningMethodRS256
+	case "ES256":
+		method = jwt.SigningMethodES256
+	default:
+		return "", fmt.Errorf("unsupported signing key algorithm: %s", kp.Alg)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kp.Kid
+
+	tokenString, err := token.SignedString(kp.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
 	return tokenString, nil
 }
 
+// parseClaims verifies a JWT signed by signClaims and returns its claims.
+func (s *AuthService) parseClaims(tokenString string) (jwt.MapClaims, error) {
+	alg := jwtAlg()
+
+	var keyFunc jwt.Keyfunc
+	if alg == "HS256" {
+		keySet, err := hsKeySetSingleton()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load HS256 signing key: %w", err)
+		}
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			// Tokens signed before kid-tagging (or by a client unaware of
+			// rotation) have no kid header; fall back to the current
+			// active key, which is correct as long as it hasn't rotated
+			// away since the token was issued.
+			kid, _ := token.Header["kid"].(string)
+			if kid == "" {
+				kp, err := keySet.Active()
+				if err != nil {
+					return nil, err
+				}
+				return kp.Secret, nil
+			}
+			kp, err := keySet.Get(kid)
+			if err != nil {
+				return nil, err
+			}
+			return kp.Secret, nil
+		}
+	} else {
+		registry, err := jwtKeyRegistry()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			kp, err := registry.Get(kid)
+			if err != nil {
+				return nil, err
+			}
+			return kp.PublicKey, nil
+		}
+	}
+
+	token, err := jwt.Parse(tokenString, keyFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
 // generateRefreshToken creates a cryptographically secure random refresh token.
 //
 // Returns a 64-character hexadecimal string.
@@ -265,120 +679,380 @@ func (s *AuthService) generateRefreshToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// RefreshToken generates new access and refresh tokens using a valid refresh token.
+// refreshTokenTTL returns the configured refresh token lifetime, defaulting
+// to 7 days. This is intentionally separate from the fixed 24h access token
+// lifetime in generateToken.
+func refreshTokenTTL() time.Duration {
+	hours := viper.GetInt("REFRESH_TOKEN_TTL_HOURS")
+	if hours <= 0 {
+		hours = 24 * 7
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// hashRefreshToken hashes an opaque refresh token for storage. Only the hash
+// is ever persisted; the raw value is returned to the client once.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueTokens generates a fresh access/refresh token pair for an already
+// authenticated user and persists the refresh token as a SHA-256 hash.
+//
+// Shared by Login, Register and any alternative authentication flow (e.g.
+// OAuth callbacks) so token issuance stays consistent across entry points.
+// parentID chains a rotated token back to the one it replaced; pass nil for
+// a brand new session (login/register).
+func (s *AuthService) IssueTokens(user *models.User, reqCtx RequestContext, parentID *uint) (*dto.AuthResponse, error) {
+	accessToken, err := s.generateToken(user)
+	if err != nil {
+		logger.Errorf("failed to generate access token: %v", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.generateRefreshToken()
+	if err != nil {
+		logger.Errorf("failed to generate refresh token: %v", err)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	roles, err := repositories.GetUserRoleNames(user.ID)
+	if err != nil {
+		logger.Errorf("failed to load user roles: %v", err)
+		return nil, fmt.Errorf("failed to load user roles: %w", err)
+	}
+
+	record := &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ParentID:  parentID,
+		UserAgent: reqCtx.UserAgent,
+		IP:        reqCtx.IP,
+		Scopes:    utils.SerializeScopes(roles),
+		ExpiresAt: time.Now().Add(refreshTokenTTL()),
+	}
+	if err := repositories.CreateRefreshToken(record); err != nil {
+		logger.Errorf("failed to save refresh token: %v", err)
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	if parentID != nil {
+		var parent models.RefreshToken
+		parent.ID = *parentID
+		revoked, err := repositories.RevokeRefreshToken(&parent, &record.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !revoked {
+			// Lost the race: another request already rotated this same
+			// parent token first. The pair we just minted is an orphan
+			// nobody holds a valid reference to; revoke it, then treat this
+			// exactly like RefreshToken's replay-detection path and take
+			// down the whole chain.
+			if _, err := repositories.RevokeRefreshToken(record, nil); err != nil {
+				logger.Errorf("failed to revoke orphaned refresh token: %v", err)
+			}
+			if full, err := repositories.GetRefreshTokenByID(*parentID); err != nil {
+				logger.Errorf("failed to load replayed refresh token for chain revocation: %v", err)
+			} else if full != nil {
+				if err := repositories.RevokeRefreshTokenChain(full); err != nil {
+					logger.Errorf("failed to revoke replayed refresh token chain: %v", err)
+				}
+			}
+			logger.Warnf("refresh token rotation race detected for user %d, revoking token chain", user.ID)
+			return nil, ErrInvalidRefreshToken
+		}
+	}
+
+	return &dto.AuthResponse{
+		User: dto.UserResponse{
+			ID:    user.ID,
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		AccessToken:   accessToken,
+		RefreshToken:  refreshToken,
+		TokenType:     "Bearer",
+		GrantedScopes: roles,
+	}, nil
+}
+
+// RefreshToken exchanges a valid refresh token for a new access/refresh pair,
+// rotating the old token out.
+//
+// If the presented token has already been rotated away (i.e. it was already
+// revoked), that is treated as a replay of a stolen token: the entire chain
+// descended from it is revoked, forcing the legitimate user to reauthenticate.
 //
-// Returns ErrInvalidRefreshToken if the refresh token is invalid or not found.
-func (s *AuthService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error) {
-	// Get user by refresh token
-	user, err := repositories.GetUserByRefreshToken(req.RefreshToken)
+// Returns ErrInvalidRefreshToken if the token is invalid, expired, or reused.
+func (s *AuthService) RefreshToken(req *dto.RefreshTokenRequest, reqCtx RequestContext) (*dto.RefreshTokenResponse, error) {
+	record, err := repositories.GetRefreshTokenByHash(hashRefreshToken(req.RefreshToken))
 	if err != nil {
-		logger.Errorf("failed to get user by refresh token: %v", err)
+		logger.Errorf("failed to look up refresh token: %v", err)
 		return nil, fmt.Errorf("failed to validate refresh token: %w", err)
 	}
 
-	if user == nil {
-		logger.Warnf("refresh token attempt with invalid token")
+	if record == nil {
+		logger.Warnf("refresh token attempt with unknown token")
 		return nil, ErrInvalidRefreshToken
 	}
 
-	// Generate new access token
-	accessToken, err := s.generateToken(user)
-	if err != nil {
-		logger.Errorf("failed to generate new access token: %v", err)
-		return nil, fmt.Errorf("failed to generate new access token: %w", err)
+	if record.RevokedAt != nil {
+		logger.Warnf("refresh token replay detected for user %d, revoking token chain", record.UserID)
+		if err := repositories.RevokeRefreshTokenChain(record); err != nil {
+			logger.Errorf("failed to revoke replayed refresh token chain: %v", err)
+		}
+		return nil, ErrInvalidRefreshToken
 	}
 
-	// Generate new refresh token
-	newRefreshToken, err := s.generateRefreshToken()
+	if time.Now().After(record.ExpiresAt) {
+		logger.Warnf("refresh token attempt with expired token for user %d", record.UserID)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := repositories.GetUserByID(record.UserID)
 	if err != nil {
-		logger.Errorf("failed to generate new refresh token: %v", err)
-		return nil, fmt.Errorf("failed to generate new refresh token: %w", err)
+		logger.Errorf("failed to load user for refresh token: %v", err)
+		return nil, fmt.Errorf("failed to validate refresh token: %w", err)
 	}
 
-	// Update refresh token in database
-	user.RefreshToken = newRefreshToken
-	if err := repositories.UpdateUser(user); err != nil {
-		logger.Errorf("failed to update refresh token: %v", err)
-		return nil, fmt.Errorf("failed to update refresh token: %w", err)
+	response, err := s.IssueTokens(user, reqCtx, &record.ID)
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Infof("refresh token successful for user: %s", user.Email)
 
-	// Build response
-	response := &dto.RefreshTokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
-		TokenType:    "Bearer",
+	return &dto.RefreshTokenResponse{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		TokenType:    response.TokenType,
+	}, nil
+}
+
+// Logout revokes a single refresh token, ending that one session/device.
+//
+// Returns ErrInvalidRefreshToken if the token is unknown.
+func (s *AuthService) Logout(req *dto.RefreshTokenRequest) error {
+	record, err := repositories.GetRefreshTokenByHash(hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+	if record == nil {
+		return ErrInvalidRefreshToken
 	}
 
-	return response, nil
+	if record.RevokedAt == nil {
+		if _, err := repositories.RevokeRefreshToken(record, nil); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("user %d logged out of one session", record.UserID)
+	return nil
+}
+
+// LogoutAll revokes every active refresh token for a user, logging them out
+// of every device at once.
+func (s *AuthService) LogoutAll(userID uint) error {
+	if err := repositories.RevokeAllRefreshTokensForUser(userID); err != nil {
+		return err
+	}
+	logger.Infof("user %d logged out of all sessions", userID)
+	return nil
 }
 
-// ForgotPassword generates a password reset token and returns it.
+// passwordResetTokenTTL is how long a password reset link stays valid.
+var passwordResetTokenTTL = repositories.TokenTTL(models.TokenTypePasswordReset)
+
+// ForgotPassword generates a password reset token and emails the reset link
+// to the account, if one exists for req.Email.
 //
-// In production, this token should be sent via email instead of returned in response.
-// Returns ErrUserNotFound if email doesn't exist.
-func (s *AuthService) ForgotPassword(req *dto.ForgotPasswordRequest) (string, error) {
+// This always succeeds from the caller's point of view: it never reports
+// whether the email is registered, so a client can't use it to enumerate
+// accounts. Delivery happens on the mailer worker pool, so a slow or
+// unreachable mail backend never delays the response.
+func (s *AuthService) ForgotPassword(req *dto.ForgotPasswordRequest) error {
 	// Get user by email
 	user, err := repositories.GetUserByEmail(req.Email)
 	if err != nil {
 		logger.Errorf("failed to get user by email: %v", err)
-		return "", fmt.Errorf("failed to process request: %w", err)
+		return fmt.Errorf("failed to process request: %w", err)
 	}
 
 	if user == nil {
-		logger.Warnf("password reset attempt for non-existent email: %s", req.Email)
-		return "", ErrUserNotFound
+		logger.Warnf("password reset requested for non-existent email: %s", req.Email)
+		return nil
+	}
+
+	// Invalidate any reset links already outstanding for this user before
+	// issuing a new one, so only the most recent email works.
+	if err := repositories.DeleteByUserAndType(user.ID, models.TokenTypePasswordReset); err != nil {
+		logger.Errorf("failed to clear previous reset tokens: %v", err)
+		return fmt.Errorf("failed to process request: %w", err)
 	}
 
 	// Generate password reset token
 	resetToken, err := s.generatePasswordResetToken()
 	if err != nil {
 		logger.Errorf("failed to generate reset token: %v", err)
-		return "", fmt.Errorf("failed to generate reset token: %w", err)
+		return fmt.Errorf("failed to generate reset token: %w", err)
 	}
 
-	// Set token expiry (15 minutes from now)
-	expiry := time.Now().Add(15 * time.Minute)
-	user.PasswordResetToken = resetToken
-	user.PasswordResetExpiry = &expiry
-
-	// Save to database
-	if err := repositories.UpdateUser(user); err != nil {
+	// Only the hash is persisted: the raw resetToken never touches the database, so a
+	// read of the tokens table can't be replayed into a working reset link.
+	if err := repositories.CreateToken(&models.Token{
+		Token:     repositories.HashToken(resetToken),
+		Type:      models.TokenTypePasswordReset,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
+	}); err != nil {
 		logger.Errorf("failed to save reset token: %v", err)
-		return "", fmt.Errorf("failed to save reset token: %w", err)
+		return fmt.Errorf("failed to save reset token: %w", err)
 	}
 
+	s.sendPasswordResetEmail(user, resetToken)
+
 	logger.Infof("password reset token generated for user: %s", user.Email)
+	return nil
+}
 
-	// TODO: In production, send this token via email instead of returning it
-	// For now, we return it for testing purposes
-	return resetToken, nil
+// sendPasswordResetEmail renders the password_reset template and queues it
+// on the mailer worker pool. Failures are logged, not returned: a mail
+// outage must not turn into a 500 that also leaks account existence.
+func (s *AuthService) sendPasswordResetEmail(user *models.User, token string) {
+	pool, templates, err := mailDependencies()
+	if err != nil {
+		logger.Errorf("mailer unavailable, could not send password reset email: %v", err)
+		return
+	}
+
+	subject, html, err := templates.Render(mailer.TemplatePasswordReset, mailer.PasswordResetData{
+		Name:             user.Name,
+		ResetURL:         passwordResetURL(token),
+		ExpiresInMinutes: int(passwordResetTokenTTL.Minutes()),
+	})
+	if err != nil {
+		logger.Errorf("failed to render password reset email: %v", err)
+		return
+	}
+
+	if err := pool.Enqueue(mailer.Message{To: user.Email, Subject: subject, HTMLBody: html}); err != nil {
+		logger.Errorf("failed to queue password reset email for %s: %v", user.Email, err)
+	}
+}
+
+// passwordResetURL builds the link a reset email points at, combining
+// FRONTEND_URL with the reset token as a query parameter.
+func passwordResetURL(token string) string {
+	base := viper.GetString("FRONTEND_URL")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(base, "/"), token)
+}
+
+// mailPool and mailTemplates are lazily initialized the first time an email
+// needs to be sent, mirroring jwtKeyRegistry's lazy setup of signing keys.
+var (
+	mailPool      *mailer.Pool
+	mailTemplates *mailer.Templates
+	mailOnce      sync.Once
+	mailErr       error
+)
+
+func mailDependencies() (*mailer.Pool, *mailer.Templates, error) {
+	mailOnce.Do(func() {
+		backend := viper.GetString("MAIL_BACKEND")
+		if backend == "" {
+			backend = mailer.BackendLog
+		}
+
+		m, err := mailer.New(mailer.Config{
+			Backend:      backend,
+			From:         viper.GetString("MAIL_FROM"),
+			SMTPHost:     viper.GetString("MAIL_SMTP_HOST"),
+			SMTPPort:     viper.GetInt("MAIL_SMTP_PORT"),
+			SMTPUsername: viper.GetString("MAIL_SMTP_USERNAME"),
+			SMTPPassword: viper.GetString("MAIL_SMTP_PASSWORD"),
+			FileDir:      viper.GetString("MAIL_FILE_DIR"),
+		})
+		if err != nil {
+			mailErr = fmt.Errorf("failed to configure mailer: %w", err)
+			return
+		}
+
+		workers := viper.GetInt("MAIL_WORKERS")
+		if workers == 0 {
+			workers = 4
+		}
+		queueSize := viper.GetInt("MAIL_QUEUE_SIZE")
+		if queueSize == 0 {
+			queueSize = 100
+		}
+		mailPool = mailer.NewPool(m, workers, queueSize)
+
+		templatesDir := viper.GetString("MAIL_TEMPLATES_DIR")
+		if templatesDir == "" {
+			templatesDir = "internal/app/templates/mail"
+		}
+		mailTemplates, mailErr = mailer.LoadTemplates(templatesDir)
+	})
+
+	return mailPool, mailTemplates, mailErr
 }
 
 // ResetPassword resets user password using a valid reset token.
 //
-// Returns ErrInvalidResetToken if token is invalid.
+// Returns ErrInvalidResetToken if token is invalid, already redeemed, or
+// not found (redeemed and unknown tokens deliberately return the same
+// error so a caller can't distinguish the two).
 // Returns ErrResetTokenExpired if token has expired.
 func (s *AuthService) ResetPassword(req *dto.ResetPasswordRequest) error {
-	// Get user by reset token
-	user, err := repositories.GetUserByPasswordResetToken(req.Token)
+	// Look up the reset token by its hash; only the hash is ever stored.
+	tokenHash := repositories.HashToken(req.Token)
+	token, err := repositories.GetByToken(models.TokenTypePasswordReset, tokenHash)
 	if err != nil {
-		logger.Errorf("failed to get user by reset token: %v", err)
+		logger.Errorf("failed to get reset token: %v", err)
 		return fmt.Errorf("failed to validate reset token: %w", err)
 	}
 
-	if user == nil {
+	if token == nil {
 		logger.Warnf("password reset attempt with invalid token")
 		return ErrInvalidResetToken
 	}
 
-	// Check if token has expired
-	if user.PasswordResetExpiry == nil || time.Now().After(*user.PasswordResetExpiry) {
-		logger.Warnf("password reset attempt with expired token for user: %s", user.Email)
+	if token.UsedAt != nil {
+		logger.Warnf("password reset attempt with already-used token for user %d", token.UserID)
+		return ErrInvalidResetToken
+	}
+
+	if token.IsExpired() {
+		logger.Warnf("password reset attempt with expired token for user %d", token.UserID)
 		return ErrResetTokenExpired
 	}
 
+	user, err := repositories.GetUserByID(token.UserID)
+	if err != nil {
+		logger.Errorf("failed to load user for reset token: %v", err)
+		return fmt.Errorf("failed to validate reset token: %w", err)
+	}
+
+	// Redeem the token before touching the password: this UPDATE only
+	// affects a row if used_at is still NULL, so of two concurrent requests
+	// racing on the same token, exactly one wins the redemption and the
+	// other is turned away here rather than both resetting the password.
+	redeemed, err := repositories.MarkTokenUsed(tokenHash)
+	if err != nil {
+		logger.Errorf("failed to mark reset token used: %v", err)
+		return fmt.Errorf("failed to validate reset token: %w", err)
+	}
+	if !redeemed {
+		logger.Warnf("password reset attempt with already-used token for user %d", token.UserID)
+		return ErrInvalidResetToken
+	}
+
 	// Hash new password
 	hashedPassword, err := s.hashPassword(req.NewPassword)
 	if err != nil {
@@ -386,10 +1060,8 @@ func (s *AuthService) ResetPassword(req *dto.ResetPasswordRequest) error {
 		return fmt.Errorf("failed to process password: %w", err)
 	}
 
-	// Update password and clear reset token
+	// Update password
 	user.Password = hashedPassword
-	user.PasswordResetToken = ""
-	user.PasswordResetExpiry = nil
 
 	// Save to database
 	if err := repositories.UpdateUser(user); err != nil {
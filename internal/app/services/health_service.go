@@ -1,83 +1,148 @@
 package services
 
 import (
+	"context"
+	"sync"
 	"time"
 
-	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
+	"github.com/spf13/viper"
 )
 
-// HealthService handles health check operations.
+// HealthService aggregates pluggable Checkers into liveness and readiness
+// reports, caching the readiness result briefly so repeated probes don't
+// hammer the database.
 type HealthService struct {
-	// Dependencies can be added here if needed
+	mu       sync.Mutex
+	checkers []Checker
+	cacheTTL time.Duration
+
+	cachedAt   time.Time
+	cachedResp *dto.HealthResponse
 }
 
-// NewHealthService creates a new HealthService instance.
+// NewHealthService creates a HealthService pre-registered with the built-in
+// master and replica database checkers and the schema version checker.
 func NewHealthService() *HealthService {
-	return &HealthService{}
+	return NewHealthServiceWithCheckers(NewMasterDBChecker(), NewReplicaDBChecker(), NewSchemaVersionChecker())
+}
+
+// NewHealthServiceWithCheckers creates a HealthService pre-registered with
+// exactly the given checkers, bypassing NewHealthService's built-in
+// database checkers. Intended for tests that want to control readiness
+// with fakeChecker without a live database backing the built-in ones.
+func NewHealthServiceWithCheckers(checkers ...Checker) *HealthService {
+	s := &HealthService{cacheTTL: healthCacheTTL()}
+	for _, c := range checkers {
+		s.Register(c)
+	}
+	return s
 }
 
-// CheckHealth performs health checks on application dependencies.
-//
-// Checks database connectivity and returns overall health status.
-// Returns "healthy" only if all checks pass.
-func (s *HealthService) CheckHealth() *dto.HealthResponse {
-	checks := make(map[string]string)
-
-	// Check database connectivity
-	dbStatus := s.checkDatabase()
-	checks["database"] = dbStatus
-
-	// Determine overall status
-	overallStatus := "healthy"
-	if dbStatus != "ok" {
-		overallStatus = "unhealthy"
+// healthCacheTTL reads HEALTH_CACHE_MS, defaulting to 1000ms.
+func healthCacheTTL() time.Duration {
+	ms := viper.GetInt("HEALTH_CACHE_MS")
+	if ms <= 0 {
+		ms = 1000
 	}
+	return time.Duration(ms) * time.Millisecond
+}
 
+// Register adds a Checker to run on every readiness check. Safe to call
+// after NewHealthService, e.g. to add a Checker for an external HTTP
+// dependency via NewHTTPChecker.
+func (s *HealthService) Register(c Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkers = append(s.checkers, c)
+}
+
+// CheckHealth performs a full readiness check, kept for the pre-existing
+// GET /health endpoint.
+func (s *HealthService) CheckHealth(ctx context.Context) *dto.HealthResponse {
+	return s.checkReady(ctx)
+}
+
+// CheckLiveness reports whether the process itself is up. It never
+// inspects dependencies, so a struggling database can't take a pod out of
+// rotation via the liveness probe and trigger an unnecessary restart.
+func (s *HealthService) CheckLiveness() *dto.HealthResponse {
 	return &dto.HealthResponse{
-		Status:    overallStatus,
+		Status:    "healthy",
 		Timestamp: time.Now(),
-		Checks:    checks,
+		Checks:    map[string]string{},
 		Uptime:    metrics.GetUptime(),
 	}
 }
 
-// GetMetrics returns application metrics.
-//
-// Returns request counters and uptime statistics.
-func (s *HealthService) GetMetrics() *dto.MetricsResponse {
-	return &dto.MetricsResponse{
-		TotalRequests:   metrics.GetTotalRequests(),
-		SuccessRequests: metrics.GetSuccessRequests(),
-		ErrorRequests:   metrics.GetErrorRequests(),
-		UptimeSeconds:   metrics.GetUptime(),
-		Timestamp:       time.Now(),
-	}
+// CheckReadiness reports whether every critical dependency is healthy, for
+// the GET /healthz/ready probe.
+func (s *HealthService) CheckReadiness(ctx context.Context) *dto.HealthResponse {
+	return s.checkReady(ctx)
 }
 
-// checkDatabase verifies database connectivity.
-//
-// Returns "ok" if database is reachable, "error" otherwise.
-func (s *HealthService) checkDatabase() string {
-	// Handle nil database (test environment)
-	if database.DB == nil {
-		logger.Warnf("health check: database not initialized")
-		return "error"
+// checkReady runs every registered checker concurrently, each bounded by
+// its own Timeout derived from ctx, and aggregates the results. Results are
+// cached for cacheTTL to protect dependencies from probe storms.
+func (s *HealthService) checkReady(ctx context.Context) *dto.HealthResponse {
+	s.mu.Lock()
+	if s.cachedResp != nil && time.Since(s.cachedAt) < s.cacheTTL {
+		cached := *s.cachedResp
+		s.mu.Unlock()
+		return &cached
 	}
+	checkers := append([]Checker(nil), s.checkers...)
+	s.mu.Unlock()
 
-	sqlDB, err := database.DB.DB()
-	if err != nil {
-		logger.Errorf("health check: failed to get database instance: %v", err)
-		return "error"
+	checks := make(map[string]string, len(checkers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	healthy := true
+
+	for _, c := range checkers {
+		wg.Add(1)
+		go func(c Checker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.Timeout())
+			defer cancel()
+
+			err := c.Check(checkCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.Errorf("health check %q failed: %v", c.Name(), err)
+				checks[c.Name()] = "error"
+				if c.Critical() {
+					healthy = false
+				}
+			} else {
+				checks[c.Name()] = "ok"
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	status := "healthy"
+	if !healthy {
+		status = "unhealthy"
 	}
 
-	// Ping database with timeout
-	if err := sqlDB.Ping(); err != nil {
-		logger.Errorf("health check: database ping failed: %v", err)
-		return "error"
+	resp := &dto.HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Checks:    checks,
+		Uptime:    metrics.GetUptime(),
 	}
 
-	return "ok"
+	cached := *resp
+	s.mu.Lock()
+	s.cachedResp = &cached
+	s.cachedAt = time.Now()
+	s.mu.Unlock()
+
+	return resp
 }
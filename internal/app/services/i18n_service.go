@@ -0,0 +1,30 @@
+package services
+
+import (
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/i18n"
+	"github.com/spf13/viper"
+)
+
+// LocaleBundle is the locale bundle middlewares.LocaleMiddleware negotiates
+// requests against, set up once at startup by SetupI18n. Left nil if no
+// locales directory is configured/found, in which case RegisterRoutes
+// skips the middleware and pkg/utils's response helpers fall back to their
+// original hardcoded English strings.
+var LocaleBundle *i18n.Bundle
+
+// SetupI18n loads the locale bundles under I18N_LOCALES_DIR (defaulting to
+// "locales") into LocaleBundle.
+func SetupI18n() error {
+	dir := viper.GetString("I18N_LOCALES_DIR")
+	if dir == "" {
+		dir = "locales"
+	}
+
+	bundle, err := i18n.LoadLocales(dir)
+	if err != nil {
+		return err
+	}
+
+	LocaleBundle = bundle
+	return nil
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	qrcode "github.com/skip2/go-qrcode"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/pquerna/otp/totp"
+)
+
+// Common errors for the MFA service
+var (
+	ErrMFAAlreadyEnabled = errors.New("2FA is already enabled for this account")
+	ErrMFANotEnabled     = errors.New("2FA is not enabled for this account")
+	ErrMFANoPendingSetup = errors.New("no pending 2FA enrollment for this account")
+	ErrInvalidMFACode    = errors.New("invalid or expired 2FA code")
+)
+
+const recoveryCodeCount = 8
+
+// MFAService drives TOTP-based two-factor authentication: enrollment,
+// activation with recovery codes, disabling, and verifying the second
+// factor during login.
+type MFAService struct {
+	auth *AuthService
+}
+
+// NewMFAService creates a new MFAService instance.
+func NewMFAService(auth *AuthService) *MFAService {
+	return &MFAService{auth: auth}
+}
+
+// Enroll generates a new TOTP secret and its otpauth:// QR code.
+//
+// The secret is not persisted as the user's active secret until Verify
+// confirms the user actually scanned it and can produce a valid code; until
+// then it is only held encrypted on the user row as a "pending" secret.
+//
+// Returns ErrMFAAlreadyEnabled if 2FA is already active.
+func (s *MFAService) Enroll(user *models.User) (*dto.MFAEnrollResponse, error) {
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	issuer := viper.GetString("JWT_ISSUER")
+	if issuer == "" {
+		issuer = "boilerplate-gin-dat"
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: user.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+
+	encrypted, err := encryptSecret(key.Secret())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+	user.MFASecretEncrypted = encrypted
+	if err := repositories.UpdateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to save pending 2FA secret: %w", err)
+	}
+
+	png, err := qrcode.Encode(key.URL(), qrcode.Medium, 256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	return &dto.MFAEnrollResponse{
+		Secret:          key.Secret(),
+		OTPAuthURL:      key.URL(),
+		QRCodePNGBase64: base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Verify confirms enrollment with a live TOTP code, activates 2FA, and
+// returns a fresh batch of one-time recovery codes.
+//
+// Returns ErrMFANoPendingSetup if Enroll was never called, and
+// ErrInvalidMFACode if the code doesn't match.
+func (s *MFAService) Verify(user *models.User, code string) (*dto.MFAVerifyResponse, error) {
+	if user.MFASecretEncrypted == "" {
+		return nil, ErrMFANoPendingSetup
+	}
+
+	secret, err := decryptSecret(user.MFASecretEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt 2FA secret: %w", err)
+	}
+
+	if !totp.Validate(code, secret) {
+		logger.Warnf("2FA enrollment verification failed for user %d", user.ID)
+		return nil, ErrInvalidMFACode
+	}
+
+	user.MFAEnabled = true
+	if err := repositories.UpdateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to activate 2FA: %w", err)
+	}
+
+	plainCodes, hashedCodes, err := generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repositories.ReplaceMFARecoveryCodes(user.ID, hashedCodes); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("2FA activated for user: %s", user.Email)
+	return &dto.MFAVerifyResponse{RecoveryCodes: plainCodes}, nil
+}
+
+// Disable turns off 2FA after confirming the user still controls the
+// authenticator, and removes any unused recovery codes.
+func (s *MFAService) Disable(user *models.User, code string) error {
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+
+	secret, err := decryptSecret(user.MFASecretEncrypted)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt 2FA secret: %w", err)
+	}
+
+	if !totp.Validate(code, secret) {
+		logger.Warnf("2FA disable attempt with invalid code for user %d", user.ID)
+		return ErrInvalidMFACode
+	}
+
+	user.MFAEnabled = false
+	user.MFASecretEncrypted = ""
+	if err := repositories.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to disable 2FA: %w", err)
+	}
+
+	if err := repositories.DeleteMFARecoveryCodes(user.ID); err != nil {
+		return err
+	}
+
+	logger.Infof("2FA disabled for user: %s", user.Email)
+	return nil
+}
+
+// VerifyLoginChallenge completes a login that was paused for 2FA: it accepts
+// either a live TOTP code or an unused recovery code, and on success issues
+// the same token pair a normal Login would.
+func (s *MFAService) VerifyLoginChallenge(req *dto.MFALoginRequest, reqCtx RequestContext) (*dto.AuthResponse, error) {
+	userID, err := s.auth.ValidateMFAPendingToken(req.MFAToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMFACode, err)
+	}
+
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	secret, err := decryptSecret(user.MFASecretEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt 2FA secret: %w", err)
+	}
+
+	if totp.Validate(req.Code, secret) {
+		return s.auth.IssueTokens(user, reqCtx, nil)
+	}
+
+	if s.consumeRecoveryCode(user.ID, req.Code) {
+		logger.Warnf("user %d logged in via 2FA recovery code", user.ID)
+		return s.auth.IssueTokens(user, reqCtx, nil)
+	}
+
+	logger.Warnf("2FA login verification failed for user %d", user.ID)
+	return nil, ErrInvalidMFACode
+}
+
+// consumeRecoveryCode checks a plaintext recovery code against every unused
+// hash on file and marks the first match as used.
+func (s *MFAService) consumeRecoveryCode(userID uint, code string) bool {
+	codes, err := repositories.GetUnusedMFARecoveryCodes(userID)
+	if err != nil {
+		logger.Errorf("failed to load recovery codes for user %d: %v", userID, err)
+		return false
+	}
+
+	for _, c := range codes {
+		if bcrypt.CompareHashAndPassword([]byte(c.CodeHash), []byte(code)) == nil {
+			if err := repositories.MarkMFARecoveryCodeUsed(c); err != nil {
+				logger.Errorf("failed to mark recovery code used for user %d: %v", userID, err)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// generateRecoveryCodes creates a fresh set of one-time recovery codes,
+// returning the plaintext values (shown once) alongside their bcrypt hashes
+// (persisted).
+func generateRecoveryCodes(userID uint) ([]string, []*models.MFARecoveryCode, error) {
+	plainCodes := make([]string, 0, recoveryCodeCount)
+	hashedCodes := make([]*models.MFARecoveryCode, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := base64.RawURLEncoding.EncodeToString(raw)
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		plainCodes = append(plainCodes, code)
+		hashedCodes = append(hashedCodes, &models.MFARecoveryCode{UserID: userID, CodeHash: string(hash)})
+	}
+
+	return plainCodes, hashedCodes, nil
+}
+
+// secretEncryptionKey derives a 32-byte AES-256 key from the app SECRET so
+// TOTP secrets are never stored in plaintext.
+func secretEncryptionKey() []byte {
+	sum := sha256.Sum256([]byte(viper.GetString("SECRET")))
+	return sum[:]
+}
+
+// encryptSecret encrypts a TOTP secret at rest using AES-256-GCM, returning
+// a base64 string of nonce||ciphertext.
+func encryptSecret(secret string) (string, error) {
+	block, err := aes.NewCipher(secretEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptSecret reverses encryptSecret.
+func decryptSecret(encoded string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(secretEncryptionKey())
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("encrypted secret is too short")
+	}
+
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(plaintext)), nil
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Common errors for the OAuth service
+var (
+	ErrUnknownOAuthProvider = errors.New("unknown oauth provider")
+	ErrOAuthEmailMissing    = errors.New("oauth provider did not return an email address")
+)
+
+// OAuthUserInfo is the normalized profile returned by every provider,
+// regardless of how each one shapes its userinfo response.
+type OAuthUserInfo struct {
+	Subject string
+	Email   string
+	Name    string
+}
+
+// OAuthService drives the "login with a third-party identity provider"
+// flow: build the authorization URL, exchange the returned code, fetch the
+// user's profile and provision/link a local models.User.
+//
+// Token issuance itself is delegated to AuthService so OAuth logins produce
+// the exact same dto.AuthResponse shape as email+password logins.
+type OAuthService struct {
+	auth *AuthService
+}
+
+// NewOAuthService creates a new OAuthService instance.
+func NewOAuthService(auth *AuthService) *OAuthService {
+	return &OAuthService{auth: auth}
+}
+
+// oauthProvider loads provider's configuration via pkg/oidc, translating
+// its ErrUnknownProvider into this package's ErrUnknownOAuthProvider so
+// OAuthController's existing error handling doesn't need to know pkg/oidc
+// exists.
+func (s *OAuthService) oauthProvider(provider string) (*oidc.Provider, error) {
+	p, err := oidc.Load(provider)
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownOAuthProvider, provider)
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// oauthConfig returns the golang.org/x/oauth2 config driving provider's
+// authorization code flow.
+func (s *OAuthService) oauthConfig(provider string) (*oauth2.Config, *oidc.Provider, error) {
+	p, err := s.oauthProvider(provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scopes := []string{"read:user", "user:email"}
+	if provider != "github" {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  p.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.AuthURL,
+			TokenURL: p.TokenURL,
+		},
+	}, p, nil
+}
+
+// verifiers caches one pkg/oidc.Verifier per JWKS URL, since each starts
+// its own background key-refresh goroutine and providers are shared across
+// every request rather than being per-call.
+var (
+	verifiersMu sync.Mutex
+	verifiers   = make(map[string]*oidc.Verifier)
+)
+
+// jwksRefreshInterval is how often a cached Verifier re-fetches its
+// provider's JWKS.
+const jwksRefreshInterval = time.Hour
+
+// verifierFor returns the cached Verifier for p's JWKS endpoint, creating
+// one the first time it's needed. Returns nil if p doesn't publish a JWKS
+// (e.g. github, which never issues an id_token).
+func verifierFor(p *oidc.Provider) *oidc.Verifier {
+	if p.JWKSURL == "" {
+		return nil
+	}
+
+	verifiersMu.Lock()
+	defer verifiersMu.Unlock()
+
+	if v, ok := verifiers[p.JWKSURL]; ok {
+		return v
+	}
+
+	v := oidc.NewVerifier(p.JWKSURL, jwksRefreshInterval)
+	verifiers[p.JWKSURL] = v
+	return v
+}
+
+// AuthCodeURL builds the provider authorization URL for the given state,
+// adding a PKCE S256 challenge derived from codeVerifier so the callback
+// exchange is bound to the client that started the flow, not just to the
+// authorization code itself.
+//
+// The caller is responsible for generating and validating the state value
+// (see oauth_controller.go, which stores it in a short-lived cookie for
+// CSRF protection), for persisting codeVerifier alongside it so it can be
+// replayed to HandleCallback, and for persisting nonce the same way so it
+// can be checked against an id_token's "nonce" claim, if the provider
+// returns one.
+func (s *OAuthService) AuthCodeURL(provider, state, codeVerifier, nonce string) (string, error) {
+	cfg, _, err := s.oauthConfig(provider)
+	if err != nil {
+		return "", err
+	}
+	return cfg.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier), oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// GenerateState creates a cryptographically random state token.
+func (s *OAuthService) GenerateState() (string, error) {
+	return randomToken(16)
+}
+
+// GenerateCodeVerifier creates a cryptographically random PKCE code
+// verifier, per RFC 7636 (43-128 characters from the unreserved URL-safe
+// alphabet; a 32-byte hex string comfortably satisfies that).
+func (s *OAuthService) GenerateCodeVerifier() (string, error) {
+	return randomToken(32)
+}
+
+// GenerateNonce creates a cryptographically random OIDC nonce, bound into
+// the authorization request and checked against the returned id_token's
+// "nonce" claim to stop the token from being replayed into a different
+// login attempt.
+func (s *OAuthService) GenerateNonce() (string, error) {
+	return randomToken(16)
+}
+
+// randomToken returns a hex-encoded random token of n bytes.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HandleCallback exchanges the authorization code, establishes the user's
+// verified identity, provisions or links a local user by email, and
+// returns the same token pair Login/Register would issue.
+//
+// codeVerifier must be the same value passed to AuthCodeURL for this flow;
+// the provider's token endpoint rejects the exchange if it doesn't hash to
+// the code_challenge sent earlier. nonce must likewise be the value passed
+// to AuthCodeURL; it's checked against the id_token's "nonce" claim when
+// the provider returns one.
+//
+// When the token response includes a verifiable id_token (google, and a
+// generic "oidc" provider with OAUTH_OIDC_JWKS_URL configured), identity
+// comes from its signature-checked claims. Otherwise (github, which never
+// issues one) identity falls back to calling the provider's userinfo
+// endpoint with the access token.
+func (s *OAuthService) HandleCallback(ctx context.Context, provider, code, codeVerifier, nonce string, reqCtx RequestContext) (*dto.AuthResponse, error) {
+	cfg, p, err := s.oauthConfig(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		logger.Errorf("oauth code exchange failed for provider %s: %v", provider, err)
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	info, err := s.resolveUserInfo(ctx, cfg, p, token, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Email == "" {
+		return nil, ErrOAuthEmailMissing
+	}
+
+	user, err := s.provisionUser(provider, info)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.auth.IssueTokens(user, reqCtx, nil)
+}
+
+// resolveUserInfo establishes the caller's identity for provider p's token
+// response: by verifying its id_token if one was returned and p publishes
+// a JWKS, falling back to calling the userinfo endpoint with the access
+// token otherwise (github never returns an id_token).
+func (s *OAuthService) resolveUserInfo(ctx context.Context, cfg *oauth2.Config, p *oidc.Provider, token *oauth2.Token, nonce string) (*OAuthUserInfo, error) {
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" || p.JWKSURL == "" {
+		return s.fetchUserInfo(ctx, cfg, token, p)
+	}
+
+	claims, err := verifierFor(p).VerifyIDToken(rawIDToken, p, nonce)
+	if err != nil {
+		logger.Errorf("id_token verification failed for provider %s: %v", p.Name, err)
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	info := &OAuthUserInfo{}
+	info.Subject, _ = claims["sub"].(string)
+	info.Email, _ = claims["email"].(string)
+	info.Name, _ = claims["name"].(string)
+
+	if info.Subject == "" {
+		return nil, fmt.Errorf("id_token from %s did not include a subject identifier", p.Name)
+	}
+
+	return info, nil
+}
+
+// fetchUserInfo calls the provider-specific userinfo endpoint and normalizes
+// the response into an OAuthUserInfo.
+func (s *OAuthService) fetchUserInfo(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token, p *oidc.Provider) (*OAuthUserInfo, error) {
+	if p.UserInfoURL == "" {
+		return nil, fmt.Errorf("%w: no userinfo endpoint configured for %s", ErrUnknownOAuthProvider, p.Name)
+	}
+
+	client := cfg.Client(ctx, token)
+	resp, err := client.Get(p.UserInfoURL)
+	if err != nil {
+		logger.Errorf("failed to fetch oauth userinfo from %s: %v", p.Name, err)
+		return nil, fmt.Errorf("failed to fetch user profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read userinfo response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo request to %s returned status %d", p.Name, resp.StatusCode)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse userinfo response: %w", err)
+	}
+
+	info := &OAuthUserInfo{}
+	switch p.Name {
+	case "github":
+		if id, ok := raw["id"].(float64); ok {
+			info.Subject = fmt.Sprintf("%.0f", id)
+		}
+		info.Email, _ = raw["email"].(string)
+		info.Name, _ = raw["name"].(string)
+	default: // google, generic oidc
+		info.Subject, _ = raw["sub"].(string)
+		info.Email, _ = raw["email"].(string)
+		info.Name, _ = raw["name"].(string)
+	}
+
+	if info.Subject == "" {
+		return nil, fmt.Errorf("userinfo response from %s did not include a subject identifier", p.Name)
+	}
+
+	return info, nil
+}
+
+// provisionUser links an existing local user to the provider identity, or
+// creates a brand new one, matching by email the same way local credential
+// registration does.
+func (s *OAuthService) provisionUser(provider string, info *OAuthUserInfo) (*models.User, error) {
+	identity, err := repositories.GetOAuthIdentity(provider, info.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up oauth identity: %w", err)
+	}
+
+	if identity != nil {
+		user, err := repositories.GetUserByID(identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load linked user: %w", err)
+		}
+		return user, nil
+	}
+
+	user, err := repositories.GetUserByEmail(info.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	if user == nil {
+		// Provider-issued accounts have no local password; generate a
+		// random one so the configured password hasher still has a hash to
+		// compare against for direct login attempts.
+		randomPassword, err := s.auth.generateRefreshToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate placeholder password: %w", err)
+		}
+		hashedPassword, err := s.auth.hashPassword(randomPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash placeholder password: %w", err)
+		}
+
+		user = &models.User{
+			Name:     info.Name,
+			Email:    info.Email,
+			Password: hashedPassword,
+		}
+		if err := repositories.CreateUser(user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		logger.Infof("provisioned new user via %s oauth login: %s", provider, user.Email)
+	}
+
+	if err := repositories.CreateOAuthIdentity(&models.OAuthIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  info.Subject,
+		Email:    info.Email,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to link oauth identity: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// MFARecoveryCode is a single one-time recovery code issued when a user
+// enables TOTP two-factor authentication. Codes are stored bcrypt-hashed and
+// consumed (UsedAt set) the first time they are redeemed.
+type MFARecoveryCode struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	CodeHash string `json:"-" gorm:"type:varchar(255);not null"`
+
+	UsedAt *time.Time `json:"used_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the database table name for MFARecoveryCode model.
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
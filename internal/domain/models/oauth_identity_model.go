@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// OAuthIdentity links a local User to a subject at an external identity
+// provider (Google, GitHub, or a generic OIDC issuer).
+//
+// A single User can have multiple linked identities so the same account
+// can be reached through more than one provider.
+type OAuthIdentity struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	// Provider is the short provider key, e.g. "google", "github", "oidc".
+	Provider string `json:"provider" gorm:"type:varchar(50);not null;uniqueIndex:idx_oauth_provider_subject"`
+
+	// Subject is the provider's stable identifier for the user (the OIDC "sub").
+	Subject string `json:"subject" gorm:"type:varchar(255);not null;uniqueIndex:idx_oauth_provider_subject"`
+
+	Email string `json:"email" gorm:"type:varchar(255)"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the database table name for OAuthIdentity model.
+func (OAuthIdentity) TableName() string {
+	return "oauth_identities"
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// RefreshToken represents a single issued refresh token.
+//
+// Only a SHA-256 hash of the opaque token value is stored; the raw value is
+// returned to the client exactly once, at issuance. Rotation forms a chain
+// via ParentID/ReplacedByID so that reusing a token that has already been
+// rotated away can be detected as a replay and the whole chain revoked.
+type RefreshToken struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	TokenHash string `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+
+	// ParentID points at the token this one was issued to replace.
+	ParentID *uint `json:"parent_id,omitempty" gorm:"index"`
+
+	// ReplacedByID points at the token that replaced this one, set once
+	// this token has been rotated.
+	ReplacedByID *uint `json:"replaced_by_id,omitempty"`
+
+	UserAgent string `json:"user_agent" gorm:"type:varchar(255)"`
+	IP        string `json:"ip" gorm:"type:varchar(64)"`
+
+	// Scopes is a comma-separated snapshot of the scopes granted to this
+	// session at issuance. Storing it on the token rather than re-deriving it
+	// from the user's current roles means a scope downgrade takes effect the
+	// next time this session refreshes, not retroactively on tokens already
+	// issued today.
+	Scopes string `json:"-" gorm:"type:varchar(512)"`
+
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the database table name for RefreshToken model.
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsActive reports whether the token can still be exchanged: not expired
+// and not revoked.
+func (t *RefreshToken) IsActive() bool {
+	return t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Role is a named permission grouping that can be assigned to a user, e.g.
+// "admin".
+//
+// This codebase does not yet have a separate table for fine-grained scopes:
+// a role's name doubles as a scope, so assigning a role such as
+// "recipes:write" also satisfies authz.RequireScope("recipes:write").
+type Role struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"type:varchar(100);uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the database table name for Role model.
+func (Role) TableName() string {
+	return "roles"
+}
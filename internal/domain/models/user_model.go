@@ -9,17 +9,18 @@ import (
 // This model is used for authentication and user management.
 // Password field stores bcrypt hashed passwords only.
 type User struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	Name      string     `json:"name" gorm:"type:varchar(255);not null"`
-	Email     string     `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
-	Password  string     `json:"-" gorm:"type:varchar(255);not null"` // Never expose in JSON
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Name     string `json:"name" gorm:"type:varchar(255);not null"`
+	Email    string `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
+	Password string `json:"-" gorm:"type:varchar(255);not null"` // Never expose in JSON
 
-	// Refresh token for JWT token refresh mechanism
-	RefreshToken string `json:"-" gorm:"type:varchar(500);index"`
+	// TOTP-based two-factor authentication
+	MFAEnabled bool `json:"mfa_enabled" gorm:"not null;default:false"`
 
-	// Password reset token and expiry for forgot password flow
-	PasswordResetToken  string     `json:"-" gorm:"type:varchar(255);index"`
-	PasswordResetExpiry *time.Time `json:"-" gorm:"type:timestamp"`
+	// MFASecretEncrypted is the TOTP secret, encrypted at rest with the app
+	// SECRET. Set to the pending secret by /api/2fa/enroll; it takes effect
+	// once /api/2fa/verify confirms a code and sets MFAEnabled.
+	MFASecretEncrypted string `json:"-" gorm:"type:varchar(255)"`
 
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// UserRole assigns a Role to a User. A user may hold several roles at once;
+// the (user_id, role_id) pair is unique so assigning the same role twice is
+// a no-op rather than a duplicate row.
+type UserRole struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_role"`
+	RoleID    uint      `json:"role_id" gorm:"not null;uniqueIndex:idx_user_role"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the database table name for UserRole model.
+func (UserRole) TableName() string {
+	return "user_roles"
+}
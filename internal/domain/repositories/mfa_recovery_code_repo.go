@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ReplaceMFARecoveryCodes deletes any existing recovery codes for a user and
+// inserts a fresh batch, used both on enrollment and on regeneration.
+func ReplaceMFARecoveryCodes(userID uint, codes []*models.MFARecoveryCode) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("failed to clear old recovery codes: %w", err)
+		}
+		if err := tx.Create(&codes).Error; err != nil {
+			return fmt.Errorf("failed to create recovery codes: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetUnusedMFARecoveryCodes retrieves every recovery code for a user that
+// has not yet been redeemed.
+func GetUnusedMFARecoveryCodes(userID uint) ([]*models.MFARecoveryCode, error) {
+	var codes []*models.MFARecoveryCode
+	err := database.DB.Where("user_id = ? AND used_at IS NULL", userID).Find(&codes).Error
+	if err != nil {
+		logger.Errorf("failed to get recovery codes for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// MarkMFARecoveryCodeUsed consumes a recovery code so it cannot be reused.
+func MarkMFARecoveryCodeUsed(code *models.MFARecoveryCode) error {
+	now := time.Now()
+	if err := database.DB.Model(code).Update("used_at", &now).Error; err != nil {
+		logger.Errorf("failed to mark recovery code used: %v", err)
+		return fmt.Errorf("failed to mark recovery code used: %w", err)
+	}
+	return nil
+}
+
+// DeleteMFARecoveryCodes removes every recovery code for a user, used when
+// 2FA is disabled.
+func DeleteMFARecoveryCodes(userID uint) error {
+	if err := database.DB.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+		logger.Errorf("failed to delete recovery codes for user %d: %v", userID, err)
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// CreateOAuthIdentity persists a new provider/subject link for a user.
+func CreateOAuthIdentity(identity *models.OAuthIdentity) error {
+	if err := database.DB.Create(identity).Error; err != nil {
+		logger.Errorf("failed to create oauth identity: %v", err)
+		return fmt.Errorf("failed to create oauth identity: %w", err)
+	}
+	return nil
+}
+
+// GetOAuthIdentity retrieves a linked identity by provider and subject.
+//
+// Returns nil if no identity is linked for that provider/subject pair.
+func GetOAuthIdentity(provider, subject string) (*models.OAuthIdentity, error) {
+	var identity models.OAuthIdentity
+	err := database.DB.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Errorf("failed to get oauth identity: %v", err)
+		return nil, fmt.Errorf("failed to get oauth identity: %w", err)
+	}
+
+	return &identity, nil
+}
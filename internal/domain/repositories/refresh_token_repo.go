@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// CreateRefreshToken persists a newly issued refresh token.
+func CreateRefreshToken(token *models.RefreshToken) error {
+	if err := database.DB.Create(token).Error; err != nil {
+		logger.Errorf("failed to create refresh token: %v", err)
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by its SHA-256 hash.
+//
+// Returns nil if no token matches the hash.
+func GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := database.DB.Where("token_hash = ?", hash).First(&token).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Errorf("failed to get refresh token by hash: %v", err)
+		return nil, fmt.Errorf("failed to get refresh token by hash: %w", err)
+	}
+
+	return &token, nil
+}
+
+// GetRefreshTokenByID retrieves a refresh token by its primary key.
+//
+// Returns nil if no token matches the id.
+func GetRefreshTokenByID(id uint) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := database.DB.First(&token, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Errorf("failed to get refresh token by id: %v", err)
+		return nil, fmt.Errorf("failed to get refresh token by id: %w", err)
+	}
+	return &token, nil
+}
+
+// RevokeRefreshToken marks a token as revoked, optionally recording the
+// token that replaced it.
+//
+// The update is conditioned on revoked_at still being NULL, so two
+// concurrent rotations of the same token race on a single row-level UPDATE
+// rather than on a separate read; only one can affect a row. Returns false
+// (with a nil error) when token was already revoked, so callers can treat
+// that as a replay rather than a server error.
+func RevokeRefreshToken(token *models.RefreshToken, replacedByID *uint) (bool, error) {
+	now := time.Now()
+	updates := map[string]interface{}{"revoked_at": now}
+	if replacedByID != nil {
+		updates["replaced_by_id"] = *replacedByID
+	}
+
+	result := database.DB.Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", token.ID).
+		Updates(updates)
+	if result.Error != nil {
+		logger.Errorf("failed to revoke refresh token: %v", result.Error)
+		return false, fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return false, nil
+	}
+
+	token.RevokedAt = &now
+	token.ReplacedByID = replacedByID
+	return true, nil
+}
+
+// RevokeRefreshTokenChain revokes every token descended from the given
+// token (following ReplacedByID forward and the given token itself),
+// used when a replayed token indicates the chain has been compromised.
+func RevokeRefreshTokenChain(root *models.RefreshToken) error {
+	now := time.Now()
+	current := root
+
+	for current != nil {
+		if current.RevokedAt == nil {
+			current.RevokedAt = &now
+			if err := database.DB.Save(current).Error; err != nil {
+				logger.Errorf("failed to revoke refresh token in chain: %v", err)
+				return fmt.Errorf("failed to revoke refresh token chain: %w", err)
+			}
+		}
+
+		if current.ReplacedByID == nil {
+			break
+		}
+
+		var next models.RefreshToken
+		if err := database.DB.First(&next, *current.ReplacedByID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return fmt.Errorf("failed to walk refresh token chain: %w", err)
+		}
+		current = &next
+	}
+
+	return nil
+}
+
+// RevokeAllRefreshTokensForUser revokes every active refresh token belonging
+// to a user, e.g. for a "log out of all devices" request.
+func RevokeAllRefreshTokensForUser(userID uint) error {
+	now := time.Now()
+	err := database.DB.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+
+	if err != nil {
+		logger.Errorf("failed to revoke all refresh tokens for user %d: %v", userID, err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// GetUserRoleNames returns the names of every role assigned to a user, in
+// no particular order.
+func GetUserRoleNames(userID uint) ([]string, error) {
+	var names []string
+	err := database.DB.Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("roles.name", &names).Error
+
+	if err != nil {
+		logger.Errorf("failed to get roles for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return names, nil
+}
+
+// AssignRole grants roleName to a user, creating the role if it doesn't
+// exist yet. Assigning a role the user already has is a no-op.
+func AssignRole(userID uint, roleName string) error {
+	var role models.Role
+	if err := database.DB.Where(models.Role{Name: roleName}).FirstOrCreate(&role).Error; err != nil {
+		logger.Errorf("failed to ensure role %q: %v", roleName, err)
+		return fmt.Errorf("failed to ensure role: %w", err)
+	}
+
+	userRole := models.UserRole{UserID: userID, RoleID: role.ID}
+	if err := database.DB.Where(userRole).FirstOrCreate(&userRole).Error; err != nil {
+		logger.Errorf("failed to assign role %q to user %d: %v", roleName, userID, err)
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceUserRoles atomically replaces every role assignment for a user
+// with roleNames, creating any role that doesn't exist yet. Used by the
+// admin role-management endpoint.
+func ReplaceUserRoles(userID uint, roleNames []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing roles: %w", err)
+		}
+
+		for _, name := range roleNames {
+			var role models.Role
+			if err := tx.Where(models.Role{Name: name}).FirstOrCreate(&role).Error; err != nil {
+				return fmt.Errorf("failed to ensure role %q: %w", name, err)
+			}
+			if err := tx.Create(&models.UserRole{UserID: userID, RoleID: role.ID}).Error; err != nil {
+				return fmt.Errorf("failed to assign role %q: %w", name, err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// HashToken returns the SHA-256 hex digest of value. Callers that hand a
+// token out to a user (e.g. a password reset link) should store only this
+// hash, so a read of the tokens table can never be turned back into a
+// usable token.
+func HashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenTTLs is the validity window for each token type, used by callers to
+// compute ExpiresAt when creating a token.
+var tokenTTLs = map[string]time.Duration{
+	models.TokenTypePasswordReset:     15 * time.Minute,
+	models.TokenTypeEmailVerification: 24 * time.Hour,
+	models.TokenTypeOAuthState:        5 * time.Minute,
+	models.TokenTypeMagicLink:         15 * time.Minute,
+}
+
+// TokenTTL returns the configured validity window for tokenType, or a
+// conservative 15-minute default if it isn't one of the well-known types.
+func TokenTTL(tokenType string) time.Duration {
+	if ttl, ok := tokenTTLs[tokenType]; ok {
+		return ttl
+	}
+	return 15 * time.Minute
+}
+
+// CreateToken stores a new token.
+func CreateToken(token *models.Token) error {
+	if err := database.DB.Create(token).Error; err != nil {
+		logger.Errorf("failed to create token: %v", err)
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+	return nil
+}
+
+// GetByToken retrieves a token of the given type by its value.
+//
+// Returns nil if no matching, unexpired-or-not token is found; callers are
+// responsible for checking IsExpired themselves.
+func GetByToken(tokenType, value string) (*models.Token, error) {
+	var token models.Token
+	err := database.DB.Where("token = ? AND type = ?", value, tokenType).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Errorf("failed to get token: %v", err)
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkTokenUsed stamps a token's UsedAt instead of deleting it, so a
+// redemption attempt against an already-used token can be recognized and
+// rejected as a replay rather than looking like an unknown token.
+//
+// The update is conditioned on used_at still being NULL, so two concurrent
+// redemptions of the same token race on a single row-level UPDATE rather
+// than on a separate read; only one can affect a row. Returns false (with a
+// nil error) when value was already used, so callers can treat that as a
+// replay rather than a server error.
+func MarkTokenUsed(value string) (bool, error) {
+	now := time.Now()
+	result := database.DB.Model(&models.Token{}).Where("token = ? AND used_at IS NULL", value).Update("used_at", now)
+	if result.Error != nil {
+		logger.Errorf("failed to mark token used: %v", result.Error)
+		return false, fmt.Errorf("failed to mark token used: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteToken deletes a single token by its value, e.g. after it's redeemed.
+func DeleteToken(value string) error {
+	if err := database.DB.Where("token = ?", value).Delete(&models.Token{}).Error; err != nil {
+		logger.Errorf("failed to delete token: %v", err)
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUserAndType deletes every token of tokenType belonging to userID,
+// e.g. to invalidate outstanding reset links when a new one is issued.
+func DeleteByUserAndType(userID uint, tokenType string) error {
+	if err := database.DB.Where("user_id = ? AND type = ?", userID, tokenType).Delete(&models.Token{}).Error; err != nil {
+		logger.Errorf("failed to delete tokens for user %d type %s: %v", userID, tokenType, err)
+		return fmt.Errorf("failed to delete tokens: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired purges every token whose ExpiresAt has passed, across all
+// types, and reports how many rows were removed.
+func DeleteExpired() (int64, error) {
+	result := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.Token{})
+	if result.Error != nil {
+		logger.Errorf("failed to delete expired tokens: %v", result.Error)
+		return 0, fmt.Errorf("failed to delete expired tokens: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// StartTokenJanitor launches a background goroutine that purges expired
+// tokens every interval until the process exits. Intended to be called
+// once from main.
+func StartTokenJanitor(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			n, err := DeleteExpired()
+			if err != nil {
+				logger.Errorf("token janitor: %v", err)
+				continue
+			}
+			if n > 0 {
+				logger.Infof("token janitor: purged %d expired token(s)", n)
+			}
+		}
+	}()
+}
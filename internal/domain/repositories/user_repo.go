@@ -10,6 +10,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrUserNotFound is returned by GetUserByID when no user matches, so
+// callers can distinguish "not found" from a genuine database failure.
+var ErrUserNotFound = errors.New("user not found")
+
 // CreateUser creates a new user in the database.
 //
 // Returns error if email already exists or database operation fails.
@@ -48,7 +52,7 @@ func GetUserByID(id uint) (*models.User, error) {
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		logger.Errorf("failed to get user by ID: %v", err)
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
@@ -57,6 +61,17 @@ func GetUserByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+// CountUsers returns the total number of registered users, used by the
+// admin bootstrap flow to detect "this is the first account".
+func CountUsers() (int64, error) {
+	var count int64
+	if err := database.DB.Model(&models.User{}).Count(&count).Error; err != nil {
+		logger.Errorf("failed to count users: %v", err)
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
 // UpdateUser updates an existing user in the database.
 func UpdateUser(user *models.User) error {
 	if err := database.DB.Save(user).Error; err != nil {
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database/migrations"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/routers"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/config"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/tlsconfig"
 
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 )
 
@@ -23,20 +28,95 @@ func main() {
 	if err := config.SetupConfig(); err != nil {
 		logger.Fatalf("config SetupConfig() error: %s", err)
 	}
+	logger.Configure()
+
+	if err := services.SetupSecretProvider(); err != nil {
+		logger.Fatalf("failed to initialize secret provider: %s", err)
+	}
 
 	// Initialize metrics tracking
 	metrics.Init()
 	logger.Infof("Metrics tracking initialized")
 
+	if err := services.SetupPasswordPolicy(); err != nil {
+		logger.Fatalf("failed to register password policy validator: %s", err)
+	}
+
+	// i18n is additive, not load-bearing: a deployment with no locales
+	// directory just keeps the original hardcoded English response strings.
+	if err := services.SetupI18n(); err != nil {
+		logger.Warnf("i18n: failed to load locales, falling back to hardcoded English strings: %s", err)
+	}
+
 	masterDSN, replicaDSN := config.DbConfiguration()
 
 	if err := database.DbConnection(masterDSN, replicaDSN); err != nil {
 		logger.Fatalf("database DbConnection error: %s", err)
 	}
-	//later separate migration
-	migrations.Migrate()
+	// DB_AUTO_MIGRATE is a dev-only escape hatch around AutoMigrate; outside
+	// of that, deployments are expected to run `go run ./cmd/migrate up`
+	// out of band, and EnsureSchemaCurrent just refuses to start serving
+	// traffic against a schema that hasn't caught up yet.
+	if viper.GetBool("DB_AUTO_MIGRATE") {
+		migrations.Migrate()
+	} else if err := migrations.EnsureSchemaCurrent(database.DB); err != nil {
+		logger.Fatalf("database schema check failed: %s", err)
+	}
+
+	// Periodically purge expired password-reset/email-verification/OAuth
+	// state tokens so the tokens table doesn't grow unbounded.
+	repositories.StartTokenJanitor(time.Hour)
+
+	// The admin surface (metrics, migration status, rate-limiter
+	// introspection) is served on its own mTLS-authenticated listener so it
+	// can be reachable only by internal scrapers with a client certificate,
+	// independent of the public listener below. Off unless an admin cert is
+	// configured.
+	if viper.GetString("ADMIN_TLS_CERT_FILE") != "" {
+		go serveAdmin()
+	}
 
 	router := routers.SetupRoute()
 	logger.Fatalf("%v", router.Run(config.ServerConfig()))
 
 }
+
+// serveAdmin starts the mTLS-gated admin listener (see
+// routers.RegisterAdminRoutes) on ADMIN_LISTEN_ADDR, requiring a client
+// certificate per ADMIN_TLS_CLIENT_AUTH (defaulting to "verify", i.e. the
+// certificate must chain to ADMIN_TLS_CLIENT_CA).
+func serveAdmin() {
+	adminRouter := gin.New()
+	routers.RegisterAdminRoutes(adminRouter)
+
+	authType := viper.GetString("ADMIN_TLS_CLIENT_AUTH")
+	if authType == "" {
+		authType = "verify"
+	}
+
+	tlsCfg, err := tlsconfig.LoadServerTLS(
+		viper.GetString("ADMIN_TLS_CERT_FILE"),
+		viper.GetString("ADMIN_TLS_KEY_FILE"),
+		viper.GetString("ADMIN_TLS_CLIENT_CA"),
+		authType,
+	)
+	if err != nil {
+		logger.Fatalf("admin TLS config error: %s", err)
+	}
+
+	addr := viper.GetString("ADMIN_LISTEN_ADDR")
+	if addr == "" {
+		addr = ":9443"
+	}
+
+	server := &http.Server{
+		Addr:      addr,
+		Handler:   adminRouter,
+		TLSConfig: tlsCfg,
+	}
+
+	logger.Infof("admin listener starting on %s (mTLS, authType=%s)", addr, authType)
+	if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		logger.Fatalf("admin listener error: %s", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package apierr defines stable, string-keyed error codes (e.g.
+// "auth.invalid_credentials") that render as RFC 7807 Problem Details
+// documents.
+//
+// A Problem is both a registry entry (Code/Status/Title/doc link) and a
+// normal Go error, so service-layer sentinel errors can be declared
+// directly as Problems:
+//
+//	var ErrInvalidCredentials = apierr.New("auth.invalid_credentials", http.StatusUnauthorized, "Invalid Credentials")
+//	...
+//	return nil, ErrInvalidCredentials
+//
+// errors.Is/errors.As keep working exactly as they would for an
+// errors.New sentinel (including through fmt.Errorf("%w: ...", ...)
+// wrapping), since a Problem is still a unique *Problem pointer. Callers
+// that only have an arbitrary error (not necessarily a *Problem) use Wrap
+// to get a renderable Problem, falling back to a generic internal-error
+// Problem when the chain doesn't contain one; As reports whether the
+// chain actually contained a registered Problem. See pkg/utils.HandleErrors
+// for where these get rendered.
+package apierr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DocsBase is the base URL "type" links are built from (see TypeURI). It's
+// a var rather than a const so deployments can point it at their own
+// published API docs.
+var DocsBase = "https://docs.example.com/errors"
+
+// Problem is a catalog error that doubles as an RFC 7807 Problem Details
+// document: Status/Title/Code map to "status"/"title"/an extension "code"
+// member, and TypeURI becomes "type". Detail carries occurrence-specific
+// context (e.g. the wrapped error's message); it's left empty on the
+// shared catalog value and set per-occurrence via WithDetail.
+type Problem struct {
+	Code   string `json:"code"`
+	Status int    `json:"-"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Error implements the error interface.
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return fmt.Sprintf("[%s] %s: %s", p.Code, p.Title, p.Detail)
+	}
+	return fmt.Sprintf("[%s] %s", p.Code, p.Title)
+}
+
+// WithDetail returns a copy of p carrying the given occurrence-specific
+// detail. Catalog entries are shared *Problem values, so callers that want
+// to attach request-specific context should call this rather than mutating
+// the returned error in place.
+func (p *Problem) WithDetail(detail string) *Problem {
+	clone := *p
+	clone.Detail = detail
+	return &clone
+}
+
+// TypeURI is the RFC 7807 "type" member: a dereferenceable URI describing
+// this problem code, or "about:blank" when p is nil (no more specific type
+// than the HTTP status itself).
+func (p *Problem) TypeURI() string {
+	if p == nil || p.Code == "" {
+		return "about:blank"
+	}
+	return DocsBase + "/" + p.Code
+}
+
+// registry holds every catalog error, keyed by Code.
+var registry = map[string]*Problem{}
+
+// New declares a catalog Problem and records it in the registry. Panics on
+// a duplicate code, same as a duplicate map key would indicate a copy-paste
+// mistake in the catalog.
+func New(code string, status int, title string) *Problem {
+	if _, exists := registry[code]; exists {
+		panic("apierr: duplicate problem code " + code)
+	}
+	p := &Problem{Code: code, Status: status, Title: title}
+	registry[code] = p
+	return p
+}
+
+// Lookup returns the registered Problem for code, if any.
+func Lookup(code string) (*Problem, bool) {
+	p, ok := registry[code]
+	return p, ok
+}
+
+// internalServer is the fallback Problem Wrap renders for an error whose
+// chain doesn't contain a registered Problem.
+var internalServer = New("internal_server_error", 500, "Internal Server Error")
+
+// As reports whether err's chain contains a registered Problem, returning
+// it if so.
+func As(err error) (*Problem, bool) {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p, true
+	}
+	return nil, false
+}
+
+// Wrap returns a renderable Problem for err: the Problem in err's chain if
+// there is one (carrying any extra fmt.Errorf("%w: ...", ...) context as
+// Detail), or internalServer wrapping err's message otherwise. Returns nil
+// for a nil err.
+func Wrap(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+	if p, ok := As(err); ok {
+		if err.Error() == p.Error() {
+			return p
+		}
+		return p.WithDetail(err.Error())
+	}
+	return internalServer.WithDetail(err.Error())
+}
@@ -0,0 +1,59 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/apierr"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrForbidden is the stable error code RequireScope/RequireAnyRole reject
+// with, so a caller parsing the RFC 7807 response (see pkg/utils.HandleErrors)
+// can distinguish "authenticated but not authorized" from other 403s.
+var ErrForbidden = apierr.New("authz.forbidden", http.StatusForbidden, "Forbidden")
+
+// scopeContextKey and rolesContextKey are the gin.Context keys
+// middlewares.AuthMiddleware populates from the validated access token.
+const (
+	scopeContextKey = "scope"
+	rolesContextKey = "roles"
+)
+
+// RequireScope builds a middleware that only lets the request through when
+// the caller's "scope" claim (a space-delimited Scope, set by
+// middlewares.AuthMiddleware) contains every scope listed. Must run after
+// AuthMiddleware.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, _ := c.Get(scopeContextKey)
+		scope, _ := granted.(Scope)
+
+		if !scope.HasAll(scopes...) {
+			utils.Forbidden(c, ErrForbidden, "You do not have the required scope to perform this action")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAnyRole builds a middleware that only lets the request through
+// when the caller's "roles" claim (a []string, set by
+// middlewares.AuthMiddleware) contains at least one of roles. Must run
+// after AuthMiddleware.
+func RequireAnyRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, _ := c.Get(rolesContextKey)
+		grantedRoles, _ := granted.([]string)
+
+		if !New(grantedRoles...).HasAny(roles...) {
+			utils.Forbidden(c, ErrForbidden, "You do not have the required role to perform this action")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
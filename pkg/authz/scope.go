@@ -0,0 +1,102 @@
+// Package authz implements OAuth2-style scope and role enforcement for
+// protected routes: a space-delimited Scope type (the shape the "scope"
+// claim takes in an RFC 7662 introspection response) plus gin middlewares
+// that read it, and the "roles" claim, out of the request context
+// AuthMiddleware populates.
+package authz
+
+import "strings"
+
+// Scope is an OAuth2 scope string: zero or more space-delimited values, the
+// same representation the "scope" member takes in a JWT or an RFC 7662
+// introspection response. The zero value is the empty scope.
+type Scope string
+
+// New builds a Scope from individual values, skipping empty ones.
+func New(values ...string) Scope {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return Scope(strings.Join(kept, " "))
+}
+
+// List returns s's individual values.
+func (s Scope) List() []string {
+	fields := strings.Fields(string(s))
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// Has reports whether value is present in s.
+func (s Scope) Has(value string) bool {
+	for _, v := range s.List() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAll reports whether every entry in values is present in s. An empty
+// values list is vacuously satisfied.
+func (s Scope) HasAll(values ...string) bool {
+	for _, v := range values {
+		if !s.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny reports whether at least one entry in values is present in s. An
+// empty values list is never satisfied, since there is nothing to match.
+func (s Scope) HasAny(values ...string) bool {
+	for _, v := range values {
+		if s.Has(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns a Scope holding every value present in s or other, each
+// appearing once.
+func (s Scope) Union(other Scope) Scope {
+	seen := make(map[string]struct{})
+	var merged []string
+	for _, v := range append(s.List(), other.List()...) {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		merged = append(merged, v)
+	}
+	return Scope(strings.Join(merged, " "))
+}
+
+// Intersect returns a Scope holding only the values present in both s and
+// other, in the order they appear in s.
+func (s Scope) Intersect(other Scope) Scope {
+	otherSet := make(map[string]struct{})
+	for _, v := range other.List() {
+		otherSet[v] = struct{}{}
+	}
+
+	var kept []string
+	for _, v := range s.List() {
+		if _, ok := otherSet[v]; ok {
+			kept = append(kept, v)
+		}
+	}
+	return Scope(strings.Join(kept, " "))
+}
+
+// String implements fmt.Stringer.
+func (s Scope) String() string {
+	return string(s)
+}
@@ -98,10 +98,124 @@ func ValidateConfig() error {
 		return fmt.Errorf("SERVER_PORT cannot be empty")
 	}
 
+	// A non-HS256 JWT algorithm signs with a key pair instead of JWT_SECRET,
+	// so it needs the key path and the OIDC claims that go with it.
+	alg := viper.GetString("JWT_ALG")
+	if alg != "" && alg != "HS256" {
+		if alg != "RS256" && alg != "ES256" {
+			return fmt.Errorf("JWT_ALG must be one of HS256, RS256, ES256")
+		}
+
+		asymmetricKeys := []string{"JWT_PRIVATE_KEY_PATH", "JWT_ISSUER", "JWT_AUDIENCE"}
+		var missingAsymmetricKeys []string
+		for _, key := range asymmetricKeys {
+			if !viper.IsSet(key) || viper.GetString(key) == "" {
+				missingAsymmetricKeys = append(missingAsymmetricKeys, key)
+			}
+		}
+		if len(missingAsymmetricKeys) > 0 {
+			return fmt.Errorf("JWT_ALG=%s requires: %s", alg, strings.Join(missingAsymmetricKeys, ", "))
+		}
+	}
+
+	// An explicitly configured password hashing algorithm must be one this
+	// app knows how to use; leaving it unset defaults to Argon2id.
+	passwordAlgo := viper.GetString("PASSWORD_HASH_ALGO")
+	if passwordAlgo != "" && passwordAlgo != "bcrypt" && passwordAlgo != "argon2id" {
+		return fmt.Errorf("PASSWORD_HASH_ALGO must be one of bcrypt, argon2id")
+	}
+
+	// An explicitly configured rate limit backend must be one
+	// middlewares.RateLimitMiddlewareWithPolicy actually has a store for;
+	// leaving it unset defaults to the in-process memory store.
+	rateLimitBackend := viper.GetString("RATE_LIMIT_BACKEND")
+	if rateLimitBackend != "" && rateLimitBackend != "memory" && rateLimitBackend != "redis" {
+		return fmt.Errorf("RATE_LIMIT_BACKEND must be one of memory, redis")
+	}
+	if rateLimitBackend == "redis" && viper.GetString("REDIS_ADDR") == "" {
+		return fmt.Errorf("RATE_LIMIT_BACKEND=redis requires REDIS_ADDR")
+	}
+
+	// A production deployment must configure a real mail backend: ForgotPassword
+	// sends the reset link by email, and the "log"/"file" dev backends would
+	// silently swallow it instead of reaching the user.
+	if IsProduction() {
+		backend := viper.GetString("MAIL_BACKEND")
+		if backend == "" || backend == "log" || backend == "file" || backend == "noop" {
+			return fmt.Errorf("MAIL_BACKEND must be set to a real backend (e.g. smtp) in production")
+		}
+
+		if backend == "smtp" {
+			smtpKeys := []string{"MAIL_FROM", "MAIL_SMTP_HOST", "MAIL_SMTP_PORT"}
+			var missingSMTPKeys []string
+			for _, key := range smtpKeys {
+				if !viper.IsSet(key) || viper.GetString(key) == "" {
+					missingSMTPKeys = append(missingSMTPKeys, key)
+				}
+			}
+			if len(missingSMTPKeys) > 0 {
+				return fmt.Errorf("MAIL_BACKEND=smtp requires: %s", strings.Join(missingSMTPKeys, ", "))
+			}
+		}
+	}
+
 	logger.Debugf("Config validation passed for all required keys")
 	return nil
 }
 
+// RateLimitBackend returns the configured middlewares.RateLimitStore
+// backend: "memory" (the default, fine for a single replica) or "redis"
+// (required once the service runs multiple replicas behind a load
+// balancer, since an in-process map can't see another replica's requests).
+func RateLimitBackend() string {
+	backend := viper.GetString("RATE_LIMIT_BACKEND")
+	if backend == "" {
+		return "memory"
+	}
+	return backend
+}
+
+// RateLimitRPS returns the configured requests-per-second limit for
+// RateLimitMiddlewareWithPolicy, defaulting to 100.
+func RateLimitRPS() int {
+	if !viper.IsSet("RATE_LIMIT_RPS") {
+		return 100
+	}
+	return viper.GetInt("RATE_LIMIT_RPS")
+}
+
+// RateLimitBurst returns the configured burst size for
+// RateLimitMiddlewareWithPolicy, defaulting to 200.
+func RateLimitBurst() int {
+	if !viper.IsSet("RATE_LIMIT_BURST") {
+		return 200
+	}
+	return viper.GetInt("RATE_LIMIT_BURST")
+}
+
+// RedisAddr returns the address (host:port) of the Redis server backing the
+// "redis" rate limit backend, defaulting to "localhost:6379".
+func RedisAddr() string {
+	addr := viper.GetString("REDIS_ADDR")
+	if addr == "" {
+		return "localhost:6379"
+	}
+	return addr
+}
+
+// RedisPassword returns the Redis AUTH password, empty when Redis requires none.
+func RedisPassword() string {
+	return viper.GetString("REDIS_PASSWORD")
+}
+
+// RedisDB returns the Redis logical database index, defaulting to 0.
+func RedisDB() int {
+	if !viper.IsSet("REDIS_DB") {
+		return 0
+	}
+	return viper.GetInt("REDIS_DB")
+}
+
 // GetString returns a string configuration value
 func GetString(key string) string {
 	return viper.GetString(key)
@@ -155,3 +269,17 @@ func IsDebugEnabled() bool {
 	// Otherwise, default to true for development, false for others
 	return IsDevelopment()
 }
+
+// CookieSecure reports whether cookies this app sets directly (e.g. the
+// OAuth state/PKCE-verifier/nonce cookies) should carry the Secure
+// attribute, so a browser never sends them over plain HTTP.
+//
+// Defaults to true outside local development. Set COOKIE_SECURE=false to
+// override for a non-TLS deployment, or COOKIE_SECURE=true to enable it
+// in development against an HTTPS tunnel.
+func CookieSecure() bool {
+	if viper.IsSet("COOKIE_SECURE") {
+		return viper.GetBool("COOKIE_SECURE")
+	}
+	return !IsDevelopment()
+}
@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// contextKey is where middlewares.LocaleMiddleware stashes the locale it
+// negotiated for a request, for Translate to read back later in the same
+// request. Kept here rather than in internal/app/middlewares so pkg/utils
+// can call Translate without importing middlewares (which itself imports
+// pkg/utils).
+const contextKey = "i18n.locale"
+
+// locale pairs a resolved language.Tag with the Bundle that resolved it.
+type locale struct {
+	bundle *Bundle
+	tag    language.Tag
+}
+
+// WithContext stashes bundle's resolution of tag on c, so Translate can
+// look up messages in the request's negotiated language later in the same
+// request.
+func WithContext(c *gin.Context, bundle *Bundle, tag language.Tag) {
+	c.Set(contextKey, locale{bundle: bundle, tag: tag})
+}
+
+// Translate looks up key in the locale stashed on c by
+// middlewares.LocaleMiddleware, interpolating params.
+//
+// ok is false if no locale middleware ran for this request (e.g. it wasn't
+// registered, or this is a non-HTTP call site with no gin.Context), or the
+// bundle has no translation for key. Callers should fall back to their own
+// default text in that case.
+func Translate(c *gin.Context, key string, params map[string]string) (text string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+
+	value, exists := c.Get(contextKey)
+	if !exists {
+		return "", false
+	}
+
+	loc, ok := value.(locale)
+	if !ok {
+		return "", false
+	}
+
+	return loc.bundle.Translate(loc.tag, key, params)
+}
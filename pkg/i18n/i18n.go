@@ -0,0 +1,133 @@
+// Package i18n resolves a per-request locale from the client's
+// Accept-Language header (or an explicit override) and renders response
+// and validation messages from JSON bundles under locales/{lang}.json, so
+// pkg/utils's English-only strings can be swapped for a translated one
+// without every caller needing to know which language the client asked
+// for.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Bundle holds every loaded locale's raw message templates and the
+// language.Matcher built from them, so a request's Accept-Language header
+// can be negotiated against exactly the languages this deployment shipped
+// bundles for.
+type Bundle struct {
+	templates map[language.Tag]map[string]string
+	tags      []language.Tag
+	matcher   language.Matcher
+}
+
+// LoadLocales reads every {lang}.json file in dir (e.g. "locales/en.json"),
+// where {lang} is a BCP 47 language tag and the JSON body maps message keys
+// (e.g. "validation.required", "http.404") to a template using
+// "{field}"/"{param}"-style placeholders.
+//
+// The first file read (in directory order) becomes the fallback locale,
+// used when a key is missing from the negotiated language's bundle.
+func LoadLocales(dir string) (*Bundle, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("i18n: read locales dir %s: %w", dir, err)
+	}
+
+	b := &Bundle{templates: make(map[language.Tag]map[string]string)}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		tag, err := language.Parse(name)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s is not a valid language tag: %w", entry.Name(), err)
+		}
+
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("i18n: read %s: %w", entry.Name(), err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			return nil, fmt.Errorf("i18n: parse %s: %w", entry.Name(), err)
+		}
+
+		b.templates[tag] = messages
+		b.tags = append(b.tags, tag)
+	}
+
+	if len(b.tags) == 0 {
+		return nil, fmt.Errorf("i18n: no locale files found in %s", dir)
+	}
+
+	b.matcher = language.NewMatcher(b.tags)
+	return b, nil
+}
+
+// Match negotiates accept (an Accept-Language header value, or a bare
+// language tag from a "?lang=" query param) against the loaded locales,
+// returning the best-matching tag. An empty or unparseable accept falls
+// back to the bundle's default (first-loaded) locale.
+func (b *Bundle) Match(accept string) language.Tag {
+	if accept == "" {
+		return b.tags[0]
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(tags) == 0 {
+		// Accept-Language failed to parse; try it as a single bare tag
+		// instead, covering the "?lang=id" query-param case.
+		if tag, err := language.Parse(accept); err == nil {
+			tags = []language.Tag{tag}
+		}
+	}
+	if len(tags) == 0 {
+		return b.tags[0]
+	}
+
+	tag, _, _ := b.matcher.Match(tags...)
+	return tag
+}
+
+// Printer returns an x/text message.Printer for tag, for handlers that want
+// locale-aware number/plural formatting alongside Translate's plain message
+// lookups.
+func (b *Bundle) Printer(tag language.Tag) *message.Printer {
+	return message.NewPrinter(tag)
+}
+
+// Translate looks up key under tag, falling back to the bundle's default
+// locale if tag's bundle doesn't have it, interpolating any "{name}"
+// placeholders in params.
+//
+// ok is false if no locale has key at all, so callers can fall back to
+// treating their own string as already-localized text. This is also how a
+// custom validator tag (e.g. "strongpassword") picks up translation: add
+// "validation.strongpassword" to a locale file and FormatValidationErrors
+// finds it automatically, without a separate validator.RegisterTranslation
+// call per tag.
+func (b *Bundle) Translate(tag language.Tag, key string, params map[string]string) (text string, ok bool) {
+	template, found := b.templates[tag][key]
+	if !found {
+		template, found = b.templates[b.tags[0]][key]
+	}
+	if !found {
+		return "", false
+	}
+
+	for name, value := range params {
+		template = strings.ReplaceAll(template, "{"+name+"}", value)
+	}
+	return template, true
+}
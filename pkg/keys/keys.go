@@ -0,0 +1,218 @@
+// Package keys manages the asymmetric key material used to sign and verify
+// JWTs, so the application can act as its own identity issuer without
+// sharing a symmetric secret with every downstream consumer.
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+)
+
+// KeyPair is a single signing key: its algorithm, a stable ID derived from
+// the public key, and both halves of the key.
+type KeyPair struct {
+	Kid        string
+	Alg        string // "RS256" or "ES256"
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+// Registry holds every currently loaded key, keyed by kid, plus which one
+// is used to sign new tokens.
+//
+// Reload can be called at any time (e.g. from an operator dropping a new
+// PEM file into the keys directory) without invalidating tokens signed by
+// keys that are still loaded — ValidateToken looks up the verifying key by
+// the token's kid header among every loaded key.
+type Registry struct {
+	mu        sync.RWMutex
+	dir       string
+	keys      map[string]*KeyPair
+	activeKid string
+}
+
+// NewRegistry loads every PEM private key found in dir and returns a
+// Registry ready to sign and verify tokens.
+//
+// The most recently modified key file becomes the active signing key;
+// every other loaded key remains valid for verification only.
+func NewRegistry(dir string) (*Registry, error) {
+	r := &Registry{dir: dir, keys: make(map[string]*KeyPair)}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload rescans the key directory and replaces the in-memory key set.
+func (r *Registry) Reload() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read key directory %s: %w", r.dir, err)
+	}
+
+	keys := make(map[string]*KeyPair)
+	var activeKid string
+	var newest int64
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pem") {
+			continue
+		}
+
+		path := filepath.Join(r.dir, entry.Name())
+		kp, err := loadKeyFile(path)
+		if err != nil {
+			logger.Warnf("skipping unreadable signing key %s: %v", path, err)
+			continue
+		}
+
+		keys[kp.Kid] = kp
+
+		info, err := entry.Info()
+		if err == nil && info.ModTime().Unix() >= newest {
+			newest = info.ModTime().Unix()
+			activeKid = kp.Kid
+		}
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("no signing keys found in %s", r.dir)
+	}
+
+	r.mu.Lock()
+	r.keys = keys
+	r.activeKid = activeKid
+	r.mu.Unlock()
+
+	logger.Infof("loaded %d signing key(s) from %s, active kid=%s", len(keys), r.dir, activeKid)
+	return nil
+}
+
+// Active returns the key currently used to sign new tokens.
+func (r *Registry) Active() (*KeyPair, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kp, ok := r.keys[r.activeKid]
+	if !ok {
+		return nil, fmt.Errorf("no active signing key loaded")
+	}
+	return kp, nil
+}
+
+// Get returns the key with the given kid, used to verify a token signed by
+// a key that may no longer be the active one.
+func (r *Registry) Get(kid string) (*KeyPair, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kp, ok := r.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key kid=%s", kid)
+	}
+	return kp, nil
+}
+
+// JWKS returns the public half of every loaded key in JSON Web Key Set
+// format, suitable for GET /.well-known/jwks.json.
+func (r *Registry) JWKS() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jwks := make([]map[string]interface{}, 0, len(r.keys))
+	for _, kp := range r.keys {
+		jwks = append(jwks, publicJWK(kp))
+	}
+
+	return map[string]interface{}{"keys": jwks}
+}
+
+func publicJWK(kp *KeyPair) map[string]interface{} {
+	switch pub := kp.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return map[string]interface{}{
+			"kty": "RSA",
+			"kid": kp.Kid,
+			"alg": kp.Alg,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return map[string]interface{}{
+			"kty": "EC",
+			"kid": kp.Kid,
+			"alg": kp.Alg,
+			"use": "sig",
+			"crv": pub.Curve.Params().Name,
+			"x":   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+			"y":   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+		}
+	default:
+		return map[string]interface{}{"kid": kp.Kid, "alg": kp.Alg}
+	}
+}
+
+// loadKeyFile parses a single PEM-encoded PKCS#8 private key and derives its
+// kid from the SHA-256 of its DER-encoded public key.
+func loadKeyFile(path string) (*KeyPair, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS8 private key: %w", err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key does not implement crypto.Signer")
+	}
+
+	var alg string
+	switch signer.(type) {
+	case *rsa.PrivateKey:
+		alg = "RS256"
+	case *ecdsa.PrivateKey:
+		alg = "ES256"
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", signer)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(pubDER)
+	kid := hex.EncodeToString(sum[:])[:16]
+
+	return &KeyPair{
+		Kid:        kid,
+		Alg:        alg,
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+	}, nil
+}
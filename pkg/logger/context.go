@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ctxKey is the context.Context key WithContext stores fields under.
+type ctxKey struct{}
+
+// Entry is a fields-bound log entry, returned by FromContext/Ctx. Its
+// Debugf/Infof/Warnf/Errorf/Fatalf methods behave like the package-level
+// helpers of the same name, but also emit the fields attached via
+// WithContext.
+type Entry = logrus.Entry
+
+// WithContext returns a new context carrying fields for ambient logging via
+// FromContext/Ctx and the request-logging middleware.
+//
+// Fields accumulate: calling WithContext again merges into, rather than
+// replaces, fields already attached to ctx.
+func WithContext(ctx context.Context, fields Fields) context.Context {
+	merged := Fields{}
+	if existing, ok := ctx.Value(ctxKey{}).(Fields); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, ctxKey{}, merged)
+}
+
+// FromContext returns a log Entry pre-populated with any fields attached to
+// ctx via WithContext, so repositories/services several calls deep can log
+// with request_id/user_id/trace_id without threading them through every
+// function signature.
+func FromContext(ctx context.Context) *Entry {
+	fields, _ := ctx.Value(ctxKey{}).(Fields)
+	return logger.WithFields(logrus.Fields(fields))
+}
+
+// Ctx is a terser alias for FromContext, for call sites like
+// logger.Ctx(ctx).Infof("user %s logged in", userID).
+func Ctx(ctx context.Context) *Entry {
+	return FromContext(ctx)
+}
@@ -2,30 +2,84 @@ package logger
 
 import (
 	"bytes"
-	"github.com/sirupsen/logrus"
 	"io"
 	"os"
 	"strings"
 	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
+// defaultLogFile is the log file Configure rotates, and the file the
+// pre-Configure default output writes to without rotation.
+const defaultLogFile = "app.log"
+
 var logger = logrus.New()
 
 func init() {
 	logger.Level = logrus.InfoLevel
 	logger.Formatter = &formatter{}
 	logger.SetReportCaller(true)
+	logger.SetOutput(defaultOutput())
+}
 
-	// Open log file
-	file, err := os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+// defaultOutput opens app.log directly (no rotation), for log lines emitted
+// before Configure runs (e.g. from main.go before config.SetupConfig).
+func defaultOutput() io.Writer {
+	file, err := os.OpenFile(defaultLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
 		// Fallback to stdout if opening file fails
-		logger.SetOutput(os.Stdout)
-	} else {
-		// Write logs to both stdout and file
-		mw := io.MultiWriter(os.Stdout, file)
-		logger.SetOutput(mw)
+		return os.Stdout
 	}
+	return io.MultiWriter(os.Stdout, file)
+}
+
+// Configure applies the LOG_FORMAT ("json" or "text", defaulting to "json"
+// outside development) and log-rotation settings (LOG_MAX_SIZE_MB,
+// LOG_MAX_AGE_DAYS, LOG_MAX_BACKUPS, LOG_COMPRESS) from config.
+//
+// Call once after config.SetupConfig(), before anything logs through a
+// format/output that depends on it.
+func Configure() {
+	logger.Formatter = newFormatter(viper.GetString("LOG_FORMAT"))
+
+	logger.SetOutput(io.MultiWriter(os.Stdout, &lumberjack.Logger{
+		Filename:   defaultLogFile,
+		MaxSize:    intSetting("LOG_MAX_SIZE_MB", 100),
+		MaxAge:     intSetting("LOG_MAX_AGE_DAYS", 28),
+		MaxBackups: intSetting("LOG_MAX_BACKUPS", 5),
+		Compress:   viper.GetBool("LOG_COMPRESS"),
+	}))
+}
+
+// newFormatter builds the logrus.Formatter for format ("json"/"text"). An
+// empty format defaults to "text" in development and "json" everywhere
+// else, so local logs stay human-readable while shipped environments emit
+// machine-parseable lines.
+func newFormatter(format string) logrus.Formatter {
+	if format == "" {
+		env := viper.GetString("APP_ENV")
+		if env == "" || env == "development" {
+			format = "text"
+		} else {
+			format = "json"
+		}
+	}
+
+	if format == "json" {
+		return &logrus.JSONFormatter{TimestampFormat: time.RFC3339}
+	}
+	return &formatter{}
+}
+
+// intSetting returns viper's int value for key, or def if key isn't set.
+func intSetting(key string, def int) int {
+	if !viper.IsSet(key) {
+		return def
+	}
+	return viper.GetInt(key)
 }
 
 // SetLogLevel sets the log level for the logger
@@ -104,4 +158,4 @@ func (f *formatter) Format(entry *logrus.Entry) ([]byte, error) {
 	sb.WriteByte('\n')
 
 	return sb.Bytes(), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileMailer "sends" mail by writing each message as a .eml file into a
+// directory, so integration tests can assert on what would have been sent
+// without touching the network.
+type fileMailer struct {
+	from string
+	dir  string
+}
+
+func newFileMailer(cfg Config) (*fileMailer, error) {
+	if cfg.FileDir == "" {
+		return nil, fmt.Errorf("file mailer: FileDir is required")
+	}
+	if err := os.MkdirAll(cfg.FileDir, 0o755); err != nil {
+		return nil, fmt.Errorf("file mailer: failed to create %s: %w", cfg.FileDir, err)
+	}
+
+	return &fileMailer{from: cfg.From, dir: cfg.FileDir}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), sanitizeFilename(msg.To))
+	path := filepath.Join(m.dir, name)
+
+	if err := os.WriteFile(path, []byte(buildEML(m.from, msg)), 0o644); err != nil {
+		return fmt.Errorf("file mailer: failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// sanitizeFilename strips characters that aren't safe in a filename from an
+// email address (e.g. "user@example.com" -> "user_example.com").
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', '@', ':', ' ':
+			return '_'
+		default:
+			return r
+		}
+	}, s)
+}
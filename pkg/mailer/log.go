@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+)
+
+// logMailer "sends" mail by printing the rendered message to the
+// application logger. It is the default backend for local development so
+// engineers can see outgoing mail without standing up an SMTP server.
+type logMailer struct {
+	from string
+}
+
+func newLogMailer(cfg Config) *logMailer {
+	return &logMailer{from: cfg.From}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	logger.Infof("mailer(log): from=%s to=%s subject=%q\n%s", m.from, msg.To, msg.Subject, msg.HTMLBody)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package mailer sends transactional email (password resets, email
+// verification, welcome messages) through a pluggable backend, so the
+// delivery mechanism can differ between local development, tests, and
+// production without the caller ever knowing which one is active.
+package mailer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message is a single outgoing email, already rendered to its final HTML
+// (and optional plain-text) body.
+type Message struct {
+	To       string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer delivers a rendered Message. Implementations must be safe for
+// concurrent use, since messages are sent from worker pool goroutines.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Backend names accepted by MAIL_BACKEND.
+const (
+	BackendSMTP = "smtp"
+	BackendLog  = "log"
+	BackendFile = "file"
+	BackendNoop = "noop"
+)
+
+// Config selects and configures a Mailer backend.
+type Config struct {
+	// Backend is one of BackendSMTP, BackendLog, BackendFile, BackendNoop.
+	Backend string
+
+	// From is the envelope/header "From" address used by every backend.
+	From string
+
+	// SMTP fields, required when Backend is BackendSMTP.
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// FileDir is the directory BackendFile writes .eml files into.
+	FileDir string
+}
+
+// New builds the Mailer selected by cfg.Backend.
+//
+// Returns an error if the backend is unknown or missing required fields, so
+// misconfiguration fails fast at startup instead of silently dropping mail.
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Backend {
+	case BackendSMTP:
+		return newSMTPMailer(cfg)
+	case BackendLog:
+		return newLogMailer(cfg), nil
+	case BackendFile:
+		return newFileMailer(cfg)
+	case BackendNoop:
+		return NewNoopMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail backend %q", cfg.Backend)
+	}
+}
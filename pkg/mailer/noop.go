@@ -0,0 +1,17 @@
+package mailer
+
+import "context"
+
+// noopMailer discards every message without doing any I/O. Intended for
+// unit tests that exercise a flow which sends mail as a side effect but
+// don't want a real backend (or even the log/file ones' disk writes).
+type noopMailer struct{}
+
+// NewNoopMailer returns a Mailer that discards everything it's given.
+func NewNoopMailer() Mailer {
+	return noopMailer{}
+}
+
+func (noopMailer) Send(ctx context.Context, msg Message) error {
+	return nil
+}
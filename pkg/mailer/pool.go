@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+)
+
+// ErrQueueFull is returned by Pool.Enqueue when the bounded queue is full,
+// so a caller in the request path can decide to drop the mail rather than
+// block the HTTP response on a slow mail backend.
+var ErrQueueFull = errors.New("mailer: send queue is full")
+
+// Pool sends mail asynchronously through a fixed number of worker
+// goroutines pulling from a bounded queue, so a slow or unreachable mail
+// backend can never make an HTTP request wait on Enqueue.
+type Pool struct {
+	mailer Mailer
+	jobs   chan Message
+	wg     sync.WaitGroup
+}
+
+// NewPool starts a Pool of workers workers, each delivering through m.
+// queueSize bounds how many messages can be waiting to be sent at once.
+func NewPool(m Mailer, workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	p := &Pool{
+		mailer: m,
+		jobs:   make(chan Message, queueSize),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+
+	return p
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for msg := range p.jobs {
+		if err := p.mailer.Send(context.Background(), msg); err != nil {
+			logger.Errorf("mailer: failed to send to %s: %v", msg.To, err)
+		}
+	}
+}
+
+// Enqueue queues msg for asynchronous delivery. It never blocks: if the
+// queue is full it returns ErrQueueFull immediately.
+func (p *Pool) Enqueue(msg Message) error {
+	select {
+	case p.jobs <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting new messages and waits for every queued message to
+// be delivered.
+func (p *Pool) Close() {
+	close(p.jobs)
+	p.wg.Wait()
+}
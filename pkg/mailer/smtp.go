@@ -0,0 +1,108 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// smtpMailer delivers mail over SMTP, using STARTTLS when the server
+// advertises it, and authenticating with SMTPUsername/SMTPPassword when a
+// username is set and the server advertises AUTH.
+type smtpMailer struct {
+	from     string
+	addr     string
+	host     string
+	username string
+	password string
+}
+
+func newSMTPMailer(cfg Config) (*smtpMailer, error) {
+	if cfg.From == "" {
+		return nil, fmt.Errorf("smtp mailer: From address is required")
+	}
+	if cfg.SMTPHost == "" || cfg.SMTPPort == 0 {
+		return nil, fmt.Errorf("smtp mailer: SMTPHost and SMTPPort are required")
+	}
+
+	return &smtpMailer{
+		from:     cfg.From,
+		addr:     fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort),
+		host:     cfg.SMTPHost,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+	}, nil
+}
+
+// Send connects to the configured SMTP server, upgrades to TLS with
+// STARTTLS if the server advertises it (otherwise the message is sent
+// without TLS), authenticates if a username is set and the server
+// advertises AUTH, and delivers msg.
+//
+// ctx is only honored for cancellation before the connection is made; the
+// net/smtp client does not support context-aware I/O.
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	client, err := smtp.Dial(m.addr)
+	if err != nil {
+		return fmt.Errorf("smtp mailer: failed to dial %s: %w", m.addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return fmt.Errorf("smtp mailer: STARTTLS failed: %w", err)
+		}
+	}
+
+	if m.username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", m.username, m.password, m.host)
+			if err := client.Auth(auth); err != nil {
+				return fmt.Errorf("smtp mailer: authentication failed: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return fmt.Errorf("smtp mailer: MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("smtp mailer: RCPT TO failed: %w", err)
+	}
+
+	wc, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp mailer: DATA failed: %w", err)
+	}
+	if _, err := wc.Write([]byte(buildEML(m.from, msg))); err != nil {
+		return fmt.Errorf("smtp mailer: failed to write message body: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("smtp mailer: failed to finish message body: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildEML renders msg as a MIME message suitable for either an SMTP DATA
+// payload or a standalone .eml file.
+func buildEML(from string, msg Message) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.HTMLBody)
+	b.WriteString("\r\n")
+
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+)
+
+// Template names, matching the file names under the templates directory
+// (without the .tmpl extension) and the keys of Templates.subjects below.
+const (
+	TemplatePasswordReset     = "password_reset"
+	TemplateEmailVerification = "email_verification"
+	TemplateWelcome           = "welcome"
+)
+
+// subjects gives each named template its default email subject line.
+var subjects = map[string]string{
+	TemplatePasswordReset:     "Reset your password",
+	TemplateEmailVerification: "Verify your email address",
+	TemplateWelcome:           "Welcome!",
+}
+
+// PasswordResetData is the typed context rendered into the password_reset
+// template.
+type PasswordResetData struct {
+	Name             string
+	ResetURL         string
+	ExpiresInMinutes int
+}
+
+// EmailVerificationData is the typed context rendered into the
+// email_verification template.
+type EmailVerificationData struct {
+	Name      string
+	VerifyURL string
+}
+
+// WelcomeData is the typed context rendered into the welcome template.
+type WelcomeData struct {
+	Name string
+}
+
+// Templates is a registry of parsed mail templates, each combined with the
+// shared layout.tmpl so every mail gets the same header/footer chrome.
+type Templates struct {
+	byName map[string]*template.Template
+}
+
+// LoadTemplates parses layout.tmpl plus every named template under dir
+// (internal/app/templates/mail in production).
+//
+// Returns an error if the layout or any named template is missing or fails
+// to parse, so a broken template fails at startup rather than on first send.
+func LoadTemplates(dir string) (*Templates, error) {
+	layout := filepath.Join(dir, "layout.tmpl")
+
+	names := []string{TemplatePasswordReset, TemplateEmailVerification, TemplateWelcome}
+	byName := make(map[string]*template.Template, len(names))
+
+	for _, name := range names {
+		t, err := template.New(filepath.Base(layout)).ParseFiles(layout, filepath.Join(dir, name+".tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("mailer: failed to parse template %q: %w", name, err)
+		}
+		byName[name] = t
+	}
+
+	return &Templates{byName: byName}, nil
+}
+
+// Render executes the named template with data against the shared layout
+// and returns the finished HTML body and its default subject line.
+func (t *Templates) Render(name string, data interface{}) (subject, html string, err error) {
+	tmpl, ok := t.byName[name]
+	if !ok {
+		return "", "", fmt.Errorf("mailer: unknown template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout.tmpl", data); err != nil {
+		return "", "", fmt.Errorf("mailer: failed to render template %q: %w", name, err)
+	}
+
+	return subjects[name], buf.String(), nil
+}
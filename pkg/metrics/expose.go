@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+// Expose renders every metric currently registered on Registry in the
+// Prometheus text exposition format
+// (https://prometheus.io/docs/instrumenting/exposition_formats/).
+//
+// GET /metrics itself is served directly by promhttp.HandlerFor; Expose
+// exists for callers (and tests) that want the rendered text without going
+// through an HTTP round trip.
+func Expose() string {
+	families, err := Registry.Gather()
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(&b, family); err != nil {
+			return ""
+		}
+	}
+	return b.String()
+}
@@ -1,59 +1,123 @@
+// Package metrics exposes application metrics as Prometheus collectors,
+// registered on a private Registry so GET /metrics can serve them with
+// promhttp.HandlerFor.
 package metrics
 
 import (
-	"sync/atomic"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	dto "github.com/prometheus/client_model/go"
 )
 
+// defaultLatencyBuckets are the histogram bucket upper bounds (in seconds)
+// used for http_request_duration_seconds, matching the Prometheus client
+// library's own defaults so dashboards built against other Go services
+// keep working unmodified against this one.
+var defaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds every collector this package registers. Exported so
+// routers can mount it directly with promhttp.HandlerFor.
+var Registry = prometheus.NewRegistry()
+
 var (
-	// startTime tracks when the application started
-	startTime time.Time
+	// requestsTotal counts completed HTTP requests, keyed by method, route
+	// and status code.
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	// requestDuration holds a latency histogram per method+route.
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests in seconds.",
+		Buckets: defaultLatencyBuckets,
+	}, []string{"method", "route"})
+
+	// requestsInFlight tracks how many requests are currently being served.
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	})
+
+	// processUptime reports GetUptime() on every scrape.
+	processUptime = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "process_uptime_seconds",
+		Help: "Time since the application started.",
+	}, func() float64 { return float64(GetUptime()) })
+
+	// rateLimitDecisions counts rate limiter outcomes, broken down by
+	// whether the request was allowed or denied.
+	rateLimitDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limit_decisions_total",
+		Help: "Total number of rate limiter decisions.",
+	}, []string{"result"})
 
-	// totalRequests tracks total number of requests
-	totalRequests int64
+	// schemaVersion reports the database's currently applied golang-migrate
+	// version, set by SetSchemaVersion once at startup (and on every
+	// readiness probe) rather than computed on scrape, since reading it
+	// requires a database round trip.
+	schemaVersion = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "schema_version",
+		Help: "Currently applied database schema migration version.",
+	})
+)
 
-	// successRequests tracks successful requests (2xx, 3xx)
-	successRequests int64
+var (
+	// startTime tracks when the application started
+	startTime time.Time
 
-	// errorRequests tracks failed requests (4xx, 5xx)
-	errorRequests int64
+	registerOnce sync.Once
 )
 
-// Init initializes the metrics package.
+// Init initializes the metrics package: registers every collector on
+// Registry (including the standard process/Go runtime collectors) and
+// records the application start time.
 //
-// Should be called once at application startup.
+// Safe to call more than once (e.g. from tests, alongside Reset): the
+// collectors are only registered the first time.
 func Init() {
+	registerOnce.Do(func() {
+		Registry.MustRegister(requestsTotal, requestDuration, requestsInFlight, processUptime, rateLimitDecisions, schemaVersion)
+		Registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		Registry.MustRegister(collectors.NewGoCollector())
+	})
 	startTime = time.Now()
 }
 
-// RecordRequest records a completed HTTP request.
-//
-// Increments counters based on HTTP status code.
-// Thread-safe using atomic operations.
-func RecordRequest(statusCode int) {
-	atomic.AddInt64(&totalRequests, 1)
-
-	// Categorize by status code
-	if statusCode >= 200 && statusCode < 400 {
-		atomic.AddInt64(&successRequests, 1)
-	} else if statusCode >= 400 {
-		atomic.AddInt64(&errorRequests, 1)
-	}
+// StartInFlight increments http_requests_in_flight and returns a function
+// that decrements it again; callers should defer the returned function.
+func StartInFlight() func() {
+	requestsInFlight.Inc()
+	return requestsInFlight.Dec
 }
 
-// GetTotalRequests returns the total number of requests handled.
-func GetTotalRequests() int64 {
-	return atomic.LoadInt64(&totalRequests)
+// RecordRequest records a completed HTTP request's status code and latency,
+// keyed by method and route so /metrics can break totals and latency
+// histograms down per endpoint instead of only reporting a global total.
+func RecordRequest(method, route string, statusCode int, duration time.Duration) {
+	status := strconv.Itoa(statusCode)
+	requestsTotal.WithLabelValues(method, route, status).Inc()
+	requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
 }
 
-// GetSuccessRequests returns the number of successful requests.
-func GetSuccessRequests() int64 {
-	return atomic.LoadInt64(&successRequests)
+// RecordRateLimitDecision records a single rate limiter outcome.
+func RecordRateLimitDecision(allowed bool) {
+	result := "denied"
+	if allowed {
+		result = "allowed"
+	}
+	rateLimitDecisions.WithLabelValues(result).Inc()
 }
 
-// GetErrorRequests returns the number of failed requests.
-func GetErrorRequests() int64 {
-	return atomic.LoadInt64(&errorRequests)
+// SetSchemaVersion records the database's currently applied golang-migrate
+// version on the schema_version gauge.
+func SetSchemaVersion(version uint) {
+	schemaVersion.Set(float64(version))
 }
 
 // GetUptime returns the application uptime in seconds.
@@ -64,12 +128,69 @@ func GetUptime() int64 {
 	return int64(time.Since(startTime).Seconds())
 }
 
-// Reset resets all metrics counters.
+// Reset clears every recorded sample without unregistering the underlying
+// collectors.
 //
-// Useful for testing purposes.
+// Useful for testing purposes. Calls Init first so a test that only ever
+// calls Reset (never Init) still sees its collectors registered on
+// Registry and reported by Gather/Expose.
 func Reset() {
-	atomic.StoreInt64(&totalRequests, 0)
-	atomic.StoreInt64(&successRequests, 0)
-	atomic.StoreInt64(&errorRequests, 0)
+	Init()
+	requestsTotal.Reset()
+	requestDuration.Reset()
+	requestsInFlight.Set(0)
+	rateLimitDecisions.Reset()
+	schemaVersion.Set(0)
 	startTime = time.Now()
 }
+
+// GetTotalRequests returns the total number of HTTP requests recorded.
+//
+// Thin wrapper over the http_requests_total counter, kept so callers that
+// only need a single number don't need to parse the Prometheus exposition
+// format themselves.
+func GetTotalRequests() int64 {
+	return sumRequestsTotal(nil)
+}
+
+// GetSuccessRequests returns the number of recorded requests with a 2xx or
+// 3xx status code.
+func GetSuccessRequests() int64 {
+	return sumRequestsTotal(func(status int) bool { return status >= 200 && status < 400 })
+}
+
+// GetErrorRequests returns the number of recorded requests with a 4xx or 5xx
+// status code.
+func GetErrorRequests() int64 {
+	return sumRequestsTotal(func(status int) bool { return status >= 400 })
+}
+
+// sumRequestsTotal sums every http_requests_total series whose status label
+// satisfies filter, or every series when filter is nil.
+func sumRequestsTotal(filter func(status int) bool) int64 {
+	ch := make(chan prometheus.Metric, 64)
+	go func() {
+		requestsTotal.Collect(ch)
+		close(ch)
+	}()
+
+	var total float64
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			continue
+		}
+
+		status := 0
+		for _, label := range pb.GetLabel() {
+			if label.GetName() == "status" {
+				status, _ = strconv.Atoi(label.GetValue())
+			}
+		}
+
+		if filter == nil || filter(status) {
+			total += pb.GetCounter().GetValue()
+		}
+	}
+	return int64(total)
+}
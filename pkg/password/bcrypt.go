@@ -0,0 +1,27 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (h *Hasher) hashBcrypt(peppered string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(peppered), h.bcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+func verifyBcrypt(hash, peppered string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(peppered))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to verify password: %w", err)
+}
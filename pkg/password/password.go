@@ -0,0 +1,164 @@
+// Package password hashes and verifies user passwords behind a single
+// Hasher type, so the application can change its hashing algorithm or
+// parameters over time without a hand-rolled migration: hashes are stored
+// in the self-describing PHC string format, so a Hasher can always tell
+// which algorithm and parameters produced a given hash and verify it
+// accordingly, even if that no longer matches its own configuration.
+package password
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported values for Config.Algo.
+const (
+	AlgoBcrypt   = "bcrypt"
+	AlgoArgon2id = "argon2id"
+)
+
+// Config configures a Hasher: which algorithm new hashes use, its cost
+// parameters, and an optional pepper mixed into every password before it
+// reaches bcrypt/argon2.
+type Config struct {
+	// Algo selects the algorithm used for new hashes. Defaults to
+	// AlgoArgon2id. Existing hashes produced by the other algorithm are
+	// still verified correctly; see Hasher.Verify.
+	Algo string
+
+	BcryptCost int
+
+	// Argon2 parameters, following the OWASP-recommended defaults
+	// (m=64MiB, t=3, p=2) when unset.
+	Argon2MemoryKiB   uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+
+	// Pepper is an optional server-side secret HMAC'd into every password
+	// before hashing, so a stolen password hash table alone isn't enough
+	// to brute-force accounts.
+	Pepper string
+}
+
+// Hasher hashes and verifies passwords using a self-describing PHC-formatted
+// hash string (e.g. "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>" or
+// "$2a$10$..." for bcrypt).
+type Hasher struct {
+	algo         string
+	pepperSecret string
+
+	bcryptCost int
+
+	argon2Memory      uint32
+	argon2Iterations  uint32
+	argon2Parallelism uint8
+}
+
+// New builds a Hasher from cfg.
+func New(cfg Config) (*Hasher, error) {
+	algo := cfg.Algo
+	if algo == "" {
+		algo = AlgoArgon2id
+	}
+	if algo != AlgoBcrypt && algo != AlgoArgon2id {
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", algo)
+	}
+
+	h := &Hasher{
+		algo:              algo,
+		pepperSecret:      cfg.Pepper,
+		bcryptCost:        cfg.BcryptCost,
+		argon2Memory:      cfg.Argon2MemoryKiB,
+		argon2Iterations:  cfg.Argon2Iterations,
+		argon2Parallelism: cfg.Argon2Parallelism,
+	}
+
+	if h.bcryptCost == 0 {
+		h.bcryptCost = bcrypt.DefaultCost
+	}
+	if h.argon2Memory == 0 {
+		h.argon2Memory = 64 * 1024 // 64 MiB
+	}
+	if h.argon2Iterations == 0 {
+		h.argon2Iterations = 3
+	}
+	if h.argon2Parallelism == 0 {
+		h.argon2Parallelism = 2
+	}
+
+	return h, nil
+}
+
+// Hash produces a new PHC-formatted hash of password using the Hasher's
+// configured algorithm and parameters.
+func (h *Hasher) Hash(plainPassword string) (string, error) {
+	peppered := h.applyPepper(plainPassword)
+
+	switch h.algo {
+	case AlgoBcrypt:
+		return h.hashBcrypt(peppered)
+	default:
+		return h.hashArgon2id(peppered)
+	}
+}
+
+// Verify reports whether plainPassword matches hash, dispatching on hash's
+// own algorithm prefix regardless of the Hasher's currently configured
+// algorithm, so a Hasher configured for Argon2id can still verify bcrypt
+// hashes left over from before a migration.
+func (h *Hasher) Verify(hash, plainPassword string) (bool, error) {
+	peppered := h.applyPepper(plainPassword)
+
+	switch algoOf(hash) {
+	case AlgoArgon2id:
+		return verifyArgon2id(hash, peppered)
+	case AlgoBcrypt:
+		return verifyBcrypt(hash, peppered)
+	default:
+		return false, fmt.Errorf("unrecognized password hash format")
+	}
+}
+
+// NeedsRehash reports whether hash was produced by a different algorithm,
+// or with different parameters, than this Hasher is currently configured to
+// use for new hashes.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	switch algoOf(hash) {
+	case AlgoArgon2id:
+		if h.algo != AlgoArgon2id {
+			return true
+		}
+		params, err := parseArgon2Params(hash)
+		if err != nil {
+			return true
+		}
+		return params.memory != h.argon2Memory ||
+			params.iterations != h.argon2Iterations ||
+			params.parallelism != h.argon2Parallelism
+	case AlgoBcrypt:
+		if h.algo != AlgoBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return true
+		}
+		return cost != h.bcryptCost
+	default:
+		return true
+	}
+}
+
+// algoOf identifies the algorithm that produced hash from its PHC prefix.
+// Returns "" if hash doesn't match a known format.
+func algoOf(hash string) string {
+	switch {
+	case len(hash) >= len("$argon2id$") && hash[:len("$argon2id$")] == "$argon2id$":
+		return AlgoArgon2id
+	case len(hash) >= 4 && hash[0] == '$' && hash[1] == '2':
+		return AlgoBcrypt
+	default:
+		return ""
+	}
+}
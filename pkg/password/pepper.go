@@ -0,0 +1,21 @@
+package password
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// applyPepper mixes the Hasher's server-side secret into plainPassword via
+// HMAC before it reaches bcrypt or argon2. HMAC'ing rather than
+// concatenating keeps the peppered value a fixed 64 hex characters
+// regardless of the password's length, which sidesteps bcrypt's 72-byte
+// input limit. A Hasher with no pepper configured is a no-op passthrough.
+func (h *Hasher) applyPepper(plainPassword string) string {
+	if h.pepperSecret == "" {
+		return plainPassword
+	}
+	mac := hmac.New(sha256.New, []byte(h.pepperSecret))
+	mac.Write([]byte(plainPassword))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,99 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+// PolicyConfig configures the character-class and blocklist rules a
+// candidate password must satisfy.
+type PolicyConfig struct {
+	// MinLength defaults to 8 when zero.
+	MinLength int
+
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+// DefaultPolicyConfig keeps the `min=8` length rule and requires no
+// character classes; Validate still rejects passwords on the
+// commonPasswords blocklist.
+func DefaultPolicyConfig() PolicyConfig {
+	return PolicyConfig{MinLength: 8}
+}
+
+// commonPasswords blocks the handful of passwords that show up at the top
+// of every breach-corpus frequency list, regardless of whether they satisfy
+// the character-class rules above (e.g. "Password1!" passes every class
+// check but is still one of the first guesses an attacker makes).
+var commonPasswords = map[string]struct{}{
+	"password": {}, "password1": {}, "password1!": {}, "123456": {}, "12345678": {},
+	"123456789": {}, "qwerty": {}, "qwerty123": {}, "letmein": {}, "welcome": {},
+	"welcome1": {}, "admin": {}, "admin123": {}, "iloveyou": {}, "monkey": {},
+	"dragon": {}, "football": {}, "abc123": {}, "111111": {}, "123123": {},
+	"sunshine": {}, "princess": {}, "trustno1": {}, "superman": {}, "changeme": {},
+}
+
+// Validate reports whether plainPassword satisfies cfg, returning the first
+// violation found as a user-facing error message.
+func (cfg PolicyConfig) Validate(plainPassword string) error {
+	minLength := cfg.MinLength
+	if minLength == 0 {
+		minLength = 8
+	}
+	if len(plainPassword) < minLength {
+		return errors.New("password is too short")
+	}
+
+	if _, blocked := commonPasswords[strings.ToLower(plainPassword)]; blocked {
+		return errors.New("password is too common")
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range plainPassword {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case cfg.RequireUppercase && !hasUpper:
+		return errors.New("password must contain an uppercase letter")
+	case cfg.RequireLowercase && !hasLower:
+		return errors.New("password must contain a lowercase letter")
+	case cfg.RequireDigit && !hasDigit:
+		return errors.New("password must contain a digit")
+	case cfg.RequireSymbol && !hasSymbol:
+		return errors.New("password must contain a symbol")
+	}
+
+	return nil
+}
+
+// RegisterStrongPasswordValidator registers a "strongpassword" validator
+// tag, backed by cfg, on the shared validator instance returned by
+// utils.GetValidator() — the same instance Gin's ShouldBindJSON uses — so
+// struct fields like RegisterRequest.Password and
+// ResetPasswordRequest.NewPassword enforce the policy automatically just by
+// adding the tag.
+//
+// Intended to be called once at startup, after the policy has been read
+// from config.
+func RegisterStrongPasswordValidator(cfg PolicyConfig) error {
+	return utils.GetValidator().RegisterValidation("strongpassword", func(fl validator.FieldLevel) bool {
+		return cfg.Validate(fl.Field().String()) == nil
+	})
+}
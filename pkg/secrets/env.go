@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvProvider resolves a secret from the application config (viper/.env),
+// the same way every secret was read before pkg/secrets existed. This is
+// the default provider, and the only one with no external dependency.
+//
+// If <name>_FILE is set, the secret is read from that file path instead
+// (trimming a trailing newline), matching the common "_FILE" convention
+// used by Docker/Kubernetes secret mounts.
+type EnvProvider struct{}
+
+// NewEnvProvider creates an EnvProvider.
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{}
+}
+
+// GetSecret implements SecretProvider.
+func (p *EnvProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	if path := viper.GetString(name + "_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from %s: %w", name, path, err)
+		}
+		return []byte(strings.TrimRight(string(raw), "\n")), nil
+	}
+
+	value := viper.GetString(name)
+	if value == "" {
+		return nil, fmt.Errorf("secret %s is not configured", name)
+	}
+	return []byte(value), nil
+}
+
+// Watch implements SecretProvider. Env/file secrets require a restart to
+// change, so the returned channel never fires.
+func (p *EnvProvider) Watch(name string) <-chan []byte {
+	return make(chan []byte)
+}
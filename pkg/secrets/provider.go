@@ -0,0 +1,19 @@
+// Package secrets abstracts where a runtime secret (e.g. the JWT signing
+// key) comes from, so production deployments can back it with a real
+// secret manager instead of a plain environment variable.
+package secrets
+
+import "context"
+
+// SecretProvider resolves a named secret's current value and, for backends
+// that support it, notifies callers when that value changes.
+type SecretProvider interface {
+	// GetSecret returns the current value of name.
+	GetSecret(ctx context.Context, name string) ([]byte, error)
+
+	// Watch returns a channel that receives name's value every time it
+	// changes. The channel is never closed; callers that no longer care
+	// should simply stop reading from it. Providers that can't detect
+	// changes (e.g. EnvProvider) return a channel that never fires.
+	Watch(name string) <-chan []byte
+}
@@ -0,0 +1,258 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+)
+
+// VaultConfig configures a VaultProvider.
+type VaultConfig struct {
+	// Addr is the Vault server address, e.g. "https://vault.internal:8200".
+	Addr string
+
+	// Token authenticates directly with a Vault token. Leave empty to use
+	// AppRole auth instead.
+	Token string
+
+	// RoleID/SecretID authenticate via the AppRole auth method when Token
+	// is empty.
+	RoleID   string
+	SecretID string
+
+	// Mount is the KV v2 secrets engine mount point, default "secret".
+	Mount string
+
+	// Path is the secret's path within the KV v2 mount. Every named secret
+	// this provider serves (e.g. JWT_SECRET) is a field inside that one
+	// secret's data map.
+	Path string
+
+	// PollInterval controls how often Watch re-reads the secret to detect
+	// changes; Vault's KV v2 API has no push/subscribe primitive. Defaults
+	// to 5 minutes.
+	PollInterval time.Duration
+}
+
+// VaultProvider reads secrets from HashiCorp Vault's KV v2 engine,
+// authenticating with either a static token or AppRole, and renews its
+// token before it expires using the TTL reported by a self-lookup.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewVaultProvider creates a VaultProvider. cfg.Mount defaults to "secret"
+// and cfg.PollInterval to 5 minutes when left zero.
+func NewVaultProvider(cfg VaultConfig) *VaultProvider {
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Minute
+	}
+	return &VaultProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		token:  cfg.Token,
+	}
+}
+
+// GetSecret implements SecretProvider, returning the field named `name`
+// from the configured KV v2 secret.
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	token, err := p.authToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("vault auth failed: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.cfg.Addr, p.cfg.Mount, p.cfg.Path)
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := p.doJSON(ctx, http.MethodGet, url, token, nil, &body); err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", p.cfg.Path, err)
+	}
+
+	value, ok := body.Data.Data[name]
+	if !ok {
+		return nil, fmt.Errorf("field %s not found in vault secret %s", name, p.cfg.Path)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("field %s in vault secret %s is not a string", name, p.cfg.Path)
+	}
+	return []byte(str), nil
+}
+
+// Watch implements SecretProvider by polling GetSecret every PollInterval
+// and forwarding a value the first time it differs from the last one seen.
+func (p *VaultProvider) Watch(name string) <-chan []byte {
+	ch := make(chan []byte, 1)
+
+	go func() {
+		ctx := context.Background()
+		var last []byte
+
+		ticker := time.NewTicker(p.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			current, err := p.GetSecret(ctx, name)
+			if err != nil {
+				logger.Errorf("vault: failed to poll secret %s: %v", name, err)
+				continue
+			}
+			if last != nil && bytes.Equal(current, last) {
+				continue
+			}
+			last = current
+			select {
+			case ch <- current:
+			default:
+				logger.Warnf("vault: watch channel for %s is full, dropping update", name)
+			}
+		}
+	}()
+
+	return ch
+}
+
+// authToken returns a valid Vault token, logging in via AppRole and/or
+// renewing the current token as its TTL (from a token self-lookup)
+// approaches expiry.
+func (p *VaultProvider) authToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == "" {
+		if err := p.loginAppRole(ctx); err != nil {
+			return "", err
+		}
+		return p.token, nil
+	}
+
+	if !p.tokenExpiry.IsZero() && time.Until(p.tokenExpiry) < time.Minute {
+		if err := p.renewSelf(ctx); err != nil {
+			logger.Warnf("vault: token renewal failed, continuing with existing token: %v", err)
+		}
+	} else if p.tokenExpiry.IsZero() {
+		if err := p.lookupSelf(ctx); err != nil {
+			logger.Warnf("vault: token self-lookup failed: %v", err)
+		}
+	}
+
+	return p.token, nil
+}
+
+// loginAppRole exchanges RoleID/SecretID for a Vault token.
+func (p *VaultProvider) loginAppRole(ctx context.Context) error {
+	url := fmt.Sprintf("%s/v1/auth/approle/login", p.cfg.Addr)
+	payload := map[string]string{"role_id": p.cfg.RoleID, "secret_id": p.cfg.SecretID}
+
+	var body struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := p.doJSON(ctx, http.MethodPost, url, "", payload, &body); err != nil {
+		return fmt.Errorf("approle login failed: %w", err)
+	}
+
+	p.token = body.Auth.ClientToken
+	p.tokenExpiry = time.Now().Add(time.Duration(body.Auth.LeaseDuration) * time.Second)
+	return nil
+}
+
+// lookupSelf calls token self-lookup to learn the TTL of a statically
+// configured token, so authToken knows when it needs renewing.
+func (p *VaultProvider) lookupSelf(ctx context.Context) error {
+	url := fmt.Sprintf("%s/v1/auth/token/lookup-self", p.cfg.Addr)
+	var body struct {
+		Data struct {
+			TTL       int  `json:"ttl"`
+			Renewable bool `json:"renewable"`
+		} `json:"data"`
+	}
+	if err := p.doJSON(ctx, http.MethodGet, url, p.token, nil, &body); err != nil {
+		return err
+	}
+	if body.Data.TTL > 0 {
+		p.tokenExpiry = time.Now().Add(time.Duration(body.Data.TTL) * time.Second)
+	}
+	return nil
+}
+
+// renewSelf extends the current token's TTL.
+func (p *VaultProvider) renewSelf(ctx context.Context) error {
+	url := fmt.Sprintf("%s/v1/auth/token/renew-self", p.cfg.Addr)
+	var body struct {
+		Auth struct {
+			LeaseDuration int `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := p.doJSON(ctx, http.MethodPost, url, p.token, nil, &body); err != nil {
+		return err
+	}
+	p.tokenExpiry = time.Now().Add(time.Duration(body.Auth.LeaseDuration) * time.Second)
+	return nil
+}
+
+// doJSON performs a Vault API request, sending payload as the JSON body
+// (when non-nil) and decoding the response into out.
+func (p *VaultProvider) doJSON(ctx context.Context, method, url, token string, payload, out interface{}) error {
+	var reqBody io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
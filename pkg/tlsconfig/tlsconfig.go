@@ -0,0 +1,76 @@
+// Package tlsconfig builds the *tls.Config the server listens with, so the
+// admin surface (and, eventually, the public one) can require a client
+// certificate the same way CrowdSec's LAPI separates a mutual-TLS "machine"
+// auth path from its user-facing one.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// ClientAuthTypes maps the authType strings accepted by LoadServerTLS to
+// their tls.ClientAuthType, for anything that needs to validate the config
+// value up front without loading certs.
+var ClientAuthTypes = map[string]tls.ClientAuthType{
+	"none":    tls.NoClientCert,
+	"request": tls.RequestClientCert,
+	"require": tls.RequireAnyClientCert,
+	"verify":  tls.RequireAndVerifyClientCert,
+}
+
+// LoadServerTLS builds a server-side *tls.Config from a cert/key pair and,
+// for authType "verify" (and optionally "request"/"require"), a CA bundle
+// used to populate ClientCAs.
+//
+// authType is one of "none", "request", "require", "verify", mirroring
+// tls.ClientAuthType:
+//   - none:    no client certificate is requested (a normal HTTPS listener).
+//   - request: a client certificate is requested but not required, and is
+//     not verified against clientCA even if presented.
+//   - require: a client certificate is required but not verified against
+//     clientCA, e.g. to accept self-signed "machine" certs.
+//   - verify:  a client certificate is required and must chain to clientCA.
+//
+// clientCA is ignored for "none"; it's required for "verify" and optional
+// for "request"/"require" (set it there to additionally populate ClientCAs
+// for handlers that want to validate the chain themselves).
+func LoadServerTLS(certFile, keyFile, clientCA, authType string) (*tls.Config, error) {
+	clientAuth, ok := ClientAuthTypes[authType]
+	if !ok {
+		return nil, fmt.Errorf("tlsconfig: unknown authType %q, must be one of none, request, require, verify", authType)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: load server cert/key: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   clientAuth,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCA == "" || authType == "none" {
+		if authType == "verify" {
+			return nil, fmt.Errorf("tlsconfig: authType %q requires clientCA", authType)
+		}
+		return cfg, nil
+	}
+
+	pem, err := os.ReadFile(clientCA)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: read client CA %s: %w", clientCA, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tlsconfig: no certificates found in client CA %s", clientCA)
+	}
+	cfg.ClientCAs = pool
+
+	return cfg, nil
+}
@@ -1,15 +1,22 @@
 package utils
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"net/http"
 
-	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"	
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/apierr"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/i18n"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/types"
 )
 
-// Default HTTP status messages mapping
+// Default HTTP status messages mapping, used when no locale bundle is
+// configured (see middlewares.LocaleMiddleware) or it has no "http.<code>"
+// entry for the negotiated language.
 var defaultHTTPMessages = map[int]string{
 	http.StatusOK:                  "OK",
 	http.StatusCreated:             "Created",
@@ -25,95 +32,212 @@ var defaultHTTPMessages = map[int]string{
 	http.StatusBadGateway:          "Bad Gateway",
 }
 
+// localizedDefaultMessage resolves the default message for an HTTP status
+// code against the locale negotiated for c (see middlewares.LocaleMiddleware),
+// falling back to defaultHTTPMessages and then fallback when no bundle is
+// configured or it has no translation for this code.
+func localizedDefaultMessage(c *gin.Context, code int, fallback string) string {
+	if translated, ok := i18n.Translate(c, fmt.Sprintf("http.%d", code), nil); ok {
+		return translated
+	}
+	if msg, ok := defaultHTTPMessages[code]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// localizedMessage treats a caller-supplied message as a translation key
+// first (e.g. "auth.invalid_credentials"), falling back to the message
+// itself verbatim when no locale has a translation for it. This lets
+// existing call sites that pass a literal English sentence keep behaving
+// exactly as before, while new call sites can adopt message keys.
+func localizedMessage(c *gin.Context, message string) string {
+	if message == "" {
+		return message
+	}
+	if translated, ok := i18n.Translate(c, message, nil); ok {
+		return translated
+	}
+	return message
+}
+
 // FormatValidationErrors converts validator.ValidationErrors into a map of field -> error message.
-// Provides human-readable validation messages for each tag.
-func FormatValidationErrors(err error) map[string]string {
+//
+// Each field's message is looked up by its validator tag as the key
+// "validation.<tag>" (e.g. "validation.required") against the locale
+// negotiated for c, interpolating "{field}"/"{param}". A custom tag (e.g.
+// "strongpassword", see pkg/password) translates the same way, just by
+// adding "validation.strongpassword" to a locale file — no separate
+// validator.RegisterTranslation call needed per tag.
+//
+// Falls back to the original hardcoded English messages when no locale
+// bundle is configured, or it has no entry for a given tag.
+func FormatValidationErrors(c *gin.Context, err error) map[string]string {
 	errors := make(map[string]string)
 
-	if errs, ok := err.(validator.ValidationErrors); ok {
-		for _, e := range errs {
-			field := e.Field()
-			var msg string
-
-			switch e.Tag() {
-			case "required":
-				msg = field + " is required"
-			case "omitempty":
-				msg = field + " is optional"
-			case "email":
-				msg = field + " must be a valid email address"
-			case "url":
-				msg = field + " must be a valid URL"
-			case "uuid":
-				msg = field + " must be a valid UUID"
-			case "len":
-				msg = field + " must be exactly " + e.Param() + " characters long"
-			case "min":
-				msg = field + " must be at least " + e.Param()
-			case "max":
-				msg = field + " must be at most " + e.Param()
-			case "lt":
-				msg = field + " must be less than " + e.Param()
-			case "lte":
-				msg = field + " must be less than or equal to " + e.Param()
-			case "gt":
-				msg = field + " must be greater than " + e.Param()
-			case "gte":
-				msg = field + " must be greater than or equal to " + e.Param()
-			case "eq":
-				msg = field + " must be equal to " + e.Param()
-			case "ne":
-				msg = field + " must not be equal to " + e.Param()
-			case "oneof":
-				msg = field + " must be one of [" + e.Param() + "]"
-			case "datetime":
-				msg = field + " must be in format " + e.Param()
-			case "numeric":
-				msg = field + " must be a numeric value"
-			case "alpha":
-				msg = field + " must contain only letters"
-			case "alphanum":
-				msg = field + " must contain only letters and numbers"
-			case "boolean":
-				msg = field + " must be a boolean value"
-			case "ip":
-				msg = field + " must be a valid IP address"
-			case "ipv4":
-				msg = field + " must be a valid IPv4 address"
-			case "ipv6":
-				msg = field + " must be a valid IPv6 address"
-			case "cidr":
-				msg = field + " must be a valid CIDR notation"
-			default:
-				msg = "Invalid value for " + field
-			}
-
-			errors[field] = msg
-
-			// Log details for easier tracing
-			logger.Errorf("[VALIDATION] field=%s tag=%s value=%v msg=%s",
-				field, e.Tag(), e.Value(), msg)
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		if err != nil {
+			logger.Errorf("[VALIDATION] %v", err)
+		}
+		return errors
+	}
+
+	for _, e := range errs {
+		field := e.Field()
+		params := map[string]string{"field": field, "param": e.Param()}
+
+		msg, ok := i18n.Translate(c, "validation."+e.Tag(), params)
+		if !ok {
+			msg = defaultValidationMessage(e)
 		}
-	} else if err != nil {
-		logger.Errorf("[VALIDATION] %v", err)
+
+		errors[field] = msg
+
+		// Log details for easier tracing
+		logger.Errorf("[VALIDATION] field=%s tag=%s value=%v msg=%s",
+			field, e.Tag(), e.Value(), msg)
 	}
 
 	return errors
 }
 
+// defaultValidationMessage is the hardcoded English fallback used when no
+// locale bundle is configured, or it has no "validation.<tag>" entry.
+func defaultValidationMessage(e validator.FieldError) string {
+	field := e.Field()
+
+	switch e.Tag() {
+	case "required":
+		return field + " is required"
+	case "omitempty":
+		return field + " is optional"
+	case "email":
+		return field + " must be a valid email address"
+	case "url":
+		return field + " must be a valid URL"
+	case "uuid":
+		return field + " must be a valid UUID"
+	case "len":
+		return field + " must be exactly " + e.Param() + " characters long"
+	case "min":
+		return field + " must be at least " + e.Param()
+	case "max":
+		return field + " must be at most " + e.Param()
+	case "lt":
+		return field + " must be less than " + e.Param()
+	case "lte":
+		return field + " must be less than or equal to " + e.Param()
+	case "gt":
+		return field + " must be greater than " + e.Param()
+	case "gte":
+		return field + " must be greater than or equal to " + e.Param()
+	case "eq":
+		return field + " must be equal to " + e.Param()
+	case "ne":
+		return field + " must not be equal to " + e.Param()
+	case "oneof":
+		return field + " must be one of [" + e.Param() + "]"
+	case "datetime":
+		return field + " must be in format " + e.Param()
+	case "numeric":
+		return field + " must be a numeric value"
+	case "alpha":
+		return field + " must contain only letters"
+	case "alphanum":
+		return field + " must contain only letters and numbers"
+	case "boolean":
+		return field + " must be a boolean value"
+	case "ip":
+		return field + " must be a valid IP address"
+	case "ipv4":
+		return field + " must be a valid IPv4 address"
+	case "ipv6":
+		return field + " must be a valid IPv6 address"
+	case "cidr":
+		return field + " must be a valid CIDR notation"
+	case "strongpassword":
+		return field + " does not meet the password policy"
+	default:
+		return "Invalid value for " + field
+	}
+}
+
+// wantsProblemJSON reports whether the caller asked for RFC 7807 Problem
+// Details via an "Accept: application/problem+json" header, as opposed to
+// the default types.ErrorResponse envelope.
+func wantsProblemJSON(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "application/problem+json")
+}
+
+// problemDocument is the RFC 7807 response body: the standard
+// type/title/status/detail/instance members, plus the extension members
+// this API adds (code, errors, trace_id).
+type problemDocument struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+	TraceID  string            `json:"trace_id,omitempty"`
+}
+
+// handleProblemErrors renders err as an RFC 7807 Problem Details document.
+// err's chain is walked via apierr.Wrap for the type/title/code/detail
+// members; validation errors still populate the "errors" extension member
+// the same way they do in the types.ErrorResponse envelope.
+func handleProblemErrors(c *gin.Context, code int, err error, message string) {
+	var validationErrors map[string]string
+	if errs, ok := err.(validator.ValidationErrors); ok {
+		validationErrors = FormatValidationErrors(c, errs)
+	}
+
+	problem := apierr.Wrap(err)
+
+	doc := problemDocument{
+		Type:     problem.TypeURI(),
+		Title:    localizedMessage(c, problem.Title),
+		Status:   problem.Status,
+		Detail:   problem.Detail,
+		Instance: c.Request.URL.Path,
+		Code:     problem.Code,
+		Errors:   validationErrors,
+		TraceID:  c.GetString("request_id"),
+	}
+	if code != 0 {
+		doc.Status = code
+	}
+	if message != "" {
+		doc.Title = localizedMessage(c, message)
+	}
+
+	c.Writer.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	c.JSON(doc.Status, doc)
+}
+
 // HandleErrors sends an error response in a standard format.
 // If the error is a validation error, it will return detailed field errors.
+//
+// Honors "Accept: application/problem+json" by rendering an RFC 7807
+// Problem Details document instead (see handleProblemErrors); the default
+// types.ErrorResponse envelope below stays the response shape for every
+// other caller, for backward compatibility.
 func HandleErrors(c *gin.Context, code int, err error, message string) {
+	if wantsProblemJSON(c) {
+		handleProblemErrors(c, code, err, message)
+		return
+	}
+
 	if message == "" {
-		if msg, ok := defaultHTTPMessages[code]; ok {
-			message = msg
-		} else {
-			message = "Error"
-		}
+		message = localizedDefaultMessage(c, code, "Error")
+	} else {
+		message = localizedMessage(c, message)
 	}
 
 	if errs, ok := err.(validator.ValidationErrors); ok {
-		validationErrors := FormatValidationErrors(errs)
+		validationErrors := FormatValidationErrors(c, errs)
 		c.JSON(code, types.ErrorResponse{
 			Success: false,
 			Message: message,
@@ -136,15 +260,26 @@ func HandleErrors(c *gin.Context, code int, err error, message string) {
 	})
 }
 
+// RespondError renders err with the status and title from its apierr
+// Problem catalog entry, if it has one (see apierr.As), falling back to a
+// plain 500 Internal Server Error otherwise. This is the single call site
+// controllers use instead of branching on errors.Is per known error and
+// picking a shortcut (BadRequest/Conflict/...) for each.
+func RespondError(c *gin.Context, err error) {
+	if problem, ok := apierr.As(err); ok {
+		HandleErrors(c, problem.Status, err, problem.Title)
+		return
+	}
+	HandleErrors(c, http.StatusInternalServerError, err, "")
+}
+
 // HandleSuccess sends a success response in a standard format.
 // If message is empty, it will fall back to default HTTP messages.
 func HandleSuccess(c *gin.Context, code int, data interface{}, message string) {
 	if message == "" {
-		if msg, ok := defaultHTTPMessages[code]; ok {
-			message = msg
-		} else {
-			message = "Success"
-		}
+		message = localizedDefaultMessage(c, code, "Success")
+	} else {
+		message = localizedMessage(c, message)
 	}
 
 	c.JSON(code, types.SuccessResponse{
@@ -223,4 +358,4 @@ func RespondErrorIntrajasa(c *gin.Context, code string, msg string) {
 			"responseMsg":  msg,
 		},
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,45 @@
+package utils
+
+import "strings"
+
+// ParseScopes splits a comma-separated scope string (as stored on
+// models.RefreshToken.Scopes or embedded in the JWT "scopes" claim) into a
+// slice, trimming whitespace and dropping empty entries.
+func ParseScopes(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, ",")
+	scopes := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			scopes = append(scopes, p)
+		}
+	}
+	return scopes
+}
+
+// SerializeScopes joins scopes into the comma-separated form ParseScopes
+// reads back.
+func SerializeScopes(scopes []string) string {
+	return strings.Join(scopes, ",")
+}
+
+// IntersectScopes returns the scopes present in both granted and required,
+// preserving the order they appear in required.
+func IntersectScopes(granted, required []string) []string {
+	grantedSet := make(map[string]struct{}, len(granted))
+	for _, s := range granted {
+		grantedSet[s] = struct{}{}
+	}
+
+	var result []string
+	for _, s := range required {
+		if _, ok := grantedSet[s]; ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
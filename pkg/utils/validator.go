@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-// validate is the singleton validator instance
+// validate is the singleton validator instance. It's the same instance Gin
+// uses internally for ShouldBindJSON's `binding:"..."` tags, so a custom
+// tag registered via GetValidator().RegisterValidation(...) applies to both
+// ValidateStruct and request binding without registering it twice.
 var validate *validator.Validate
 
 func init() {
-	validate = validator.New()
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate = v
+	} else {
+		validate = validator.New()
+	}
 }
 
 // ValidateStruct validates a struct using go-playground/validator tags.
@@ -70,6 +78,8 @@ func formatValidationError(e validator.FieldError) string {
 		return fmt.Sprintf("%s must be a valid URL", field)
 	case "uuid":
 		return fmt.Sprintf("%s must be a valid UUID", field)
+	case "strongpassword":
+		return fmt.Sprintf("%s does not meet the password policy", field)
 	default:
 		return fmt.Sprintf("%s failed validation for '%s'", field, tag)
 	}
@@ -0,0 +1,70 @@
+package apierr_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/apierr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSample = apierr.New("apierr_test.sample", http.StatusTeapot, "Sample Problem")
+
+func TestNewPanicsOnDuplicateCode(t *testing.T) {
+	defer func() {
+		assert.NotNil(t, recover(), "expected a panic registering a duplicate code")
+	}()
+	apierr.New("apierr_test.sample", http.StatusTeapot, "Sample Problem")
+}
+
+func TestLookup(t *testing.T) {
+	p, ok := apierr.Lookup("apierr_test.sample")
+	require.True(t, ok)
+	assert.Equal(t, errSample, p)
+
+	_, ok = apierr.Lookup("apierr_test.does_not_exist")
+	assert.False(t, ok)
+}
+
+func TestAsFindsProblemThroughWrapping(t *testing.T) {
+	wrapped := fmt.Errorf("context: %w", errSample)
+
+	p, ok := apierr.As(wrapped)
+	require.True(t, ok)
+	assert.Equal(t, errSample, p)
+
+	_, ok = apierr.As(errors.New("not a problem"))
+	assert.False(t, ok)
+}
+
+func TestWrapReturnsSharedProblemWhenUnwrapped(t *testing.T) {
+	p := apierr.Wrap(errSample)
+	assert.Same(t, errSample, p)
+}
+
+func TestWrapCarriesWrappingContextAsDetail(t *testing.T) {
+	wrapped := fmt.Errorf("extra context: %w", errSample)
+
+	p := apierr.Wrap(wrapped)
+	assert.Equal(t, errSample.Code, p.Code)
+	assert.Equal(t, wrapped.Error(), p.Detail)
+}
+
+func TestWrapFallsBackToInternalServerError(t *testing.T) {
+	p := apierr.Wrap(errors.New("something unexpected"))
+	assert.Equal(t, "internal_server_error", p.Code)
+	assert.Equal(t, http.StatusInternalServerError, p.Status)
+	assert.Equal(t, "something unexpected", p.Detail)
+}
+
+func TestWrapNilIsNil(t *testing.T) {
+	assert.Nil(t, apierr.Wrap(nil))
+}
+
+func TestTypeURI(t *testing.T) {
+	assert.Equal(t, "about:blank", (*apierr.Problem)(nil).TypeURI())
+	assert.Equal(t, apierr.DocsBase+"/apierr_test.sample", errSample.TypeURI())
+}
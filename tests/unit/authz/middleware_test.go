@@ -0,0 +1,95 @@
+package authz_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/authz"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// withAuthz injects "scope" and "roles" values into the gin.Context as
+// middlewares.AuthMiddleware would, so RequireScope/RequireAnyRole can be
+// tested without a real JWT.
+func withAuthz(scope authz.Scope, roles []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("scope", scope)
+		c.Set("roles", roles)
+	}
+}
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Run("allows a request that holds every required scope", func(t *testing.T) {
+		router := setupTestRouter()
+		router.GET("/users", withAuthz(authz.New("users:read", "users:write"), nil), authz.RequireScope("users:read"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects a request missing a required scope", func(t *testing.T) {
+		router := setupTestRouter()
+		router.GET("/users", withAuthz(authz.New("users:write"), nil), authz.RequireScope("users:read"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects a request with no scope at all", func(t *testing.T) {
+		router := setupTestRouter()
+		router.GET("/users", authz.RequireScope("users:read"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestRequireAnyRole(t *testing.T) {
+	t.Run("allows a request holding one of the listed roles", func(t *testing.T) {
+		router := setupTestRouter()
+		router.GET("/admin", withAuthz("", []string{"staff"}), authz.RequireAnyRole("admin", "staff"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects a request holding none of the listed roles", func(t *testing.T) {
+		router := setupTestRouter()
+		router.GET("/admin", withAuthz("", []string{"member"}), authz.RequireAnyRole("admin", "staff"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
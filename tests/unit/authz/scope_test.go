@@ -0,0 +1,58 @@
+package authz_test
+
+import (
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/authz"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, authz.Scope("admin recipes:write"), authz.New("admin", "recipes:write"))
+	assert.Equal(t, authz.Scope("admin"), authz.New(" admin ", "", "  "))
+	assert.Equal(t, authz.Scope(""), authz.New())
+}
+
+func TestScopeList(t *testing.T) {
+	assert.Equal(t, []string{"admin", "recipes:write"}, authz.Scope("admin recipes:write").List())
+	assert.Nil(t, authz.Scope("").List())
+}
+
+func TestScopeHas(t *testing.T) {
+	scope := authz.Scope("admin recipes:write")
+
+	assert.True(t, scope.Has("admin"))
+	assert.False(t, scope.Has("billing:read"))
+}
+
+func TestScopeHasAll(t *testing.T) {
+	scope := authz.Scope("admin recipes:write")
+
+	assert.True(t, scope.HasAll("admin"))
+	assert.True(t, scope.HasAll("admin", "recipes:write"))
+	assert.False(t, scope.HasAll("admin", "billing:read"))
+	assert.True(t, scope.HasAll())
+}
+
+func TestScopeHasAny(t *testing.T) {
+	scope := authz.Scope("admin recipes:write")
+
+	assert.True(t, scope.HasAny("billing:read", "admin"))
+	assert.False(t, scope.HasAny("billing:read", "billing:write"))
+	assert.False(t, scope.HasAny())
+}
+
+func TestScopeUnion(t *testing.T) {
+	a := authz.Scope("admin recipes:write")
+	b := authz.Scope("recipes:write billing:read")
+
+	assert.Equal(t, authz.Scope("admin recipes:write billing:read"), a.Union(b))
+}
+
+func TestScopeIntersect(t *testing.T) {
+	a := authz.Scope("admin recipes:write")
+	b := authz.Scope("recipes:write billing:read")
+
+	assert.Equal(t, authz.Scope("recipes:write"), a.Intersect(b))
+	assert.Equal(t, authz.Scope(""), a.Intersect(authz.Scope("billing:read")))
+}
@@ -5,12 +5,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/controllers"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/dto"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
 	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,10 +44,10 @@ func TestHealthController_Health(t *testing.T) {
 		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
 
 		var response struct {
-			Success bool                `json:"success"`
-			Message string              `json:"message"`
-			Data    dto.HealthResponse  `json:"data"`
-			Errors  interface{}         `json:"errors"`
+			Success bool               `json:"success"`
+			Message string             `json:"message"`
+			Data    dto.HealthResponse `json:"data"`
+			Errors  interface{}        `json:"errors"`
 		}
 
 		err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -89,6 +92,51 @@ func TestHealthController_Health(t *testing.T) {
 	})
 }
 
+// TestHealthController_Live tests the liveness probe endpoint
+func TestHealthController_Live(t *testing.T) {
+	service := services.NewHealthService()
+	controller := controllers.NewHealthController(service)
+
+	router := setupHealthTestRouter()
+	router.GET("/healthz/live", controller.Live)
+
+	req, _ := http.NewRequest(http.MethodGet, "/healthz/live", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.HealthResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "healthy", response.Data.Status)
+}
+
+// TestHealthController_Ready tests the readiness probe endpoint
+func TestHealthController_Ready(t *testing.T) {
+	service := services.NewHealthService()
+	controller := controllers.NewHealthController(service)
+
+	router := setupHealthTestRouter()
+	router.GET("/healthz/ready", controller.Ready)
+
+	req, _ := http.NewRequest(http.MethodGet, "/healthz/ready", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// In the test environment without a database, the master DB checker
+	// is critical and fails, so readiness should report 503.
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response struct {
+		Data dto.HealthResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "unhealthy", response.Data.Status)
+	assert.Equal(t, "error", response.Data.Checks["database"])
+}
+
 // TestHealthController_Metrics tests the metrics endpoint
 func TestHealthController_Metrics(t *testing.T) {
 	// Reset and initialize metrics
@@ -98,7 +146,12 @@ func TestHealthController_Metrics(t *testing.T) {
 	service := services.NewHealthService()
 	controller := controllers.NewHealthController(service)
 
-	t.Run("Returns metrics successfully", func(t *testing.T) {
+	t.Run("Returns Prometheus text exposition format", func(t *testing.T) {
+		// A CounterVec/HistogramVec with no recorded observations reports
+		// no series (and so no # TYPE/# HELP lines) from Gather(); record
+		// one request of each kind so both appear below.
+		metrics.RecordRequest(http.MethodGet, "/widgets", 200, 5*time.Millisecond)
+
 		router := setupHealthTestRouter()
 		router.GET("/metrics", controller.Metrics)
 
@@ -107,19 +160,19 @@ func TestHealthController_Metrics(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "# TYPE http_requests_total counter")
+		assert.Contains(t, w.Body.String(), "# TYPE http_request_duration_seconds histogram")
+		assert.Contains(t, w.Body.String(), "process_uptime_seconds")
+	})
 
-		var response struct {
-			Success bool                 `json:"success"`
-			Data    dto.MetricsResponse  `json:"data"`
-		}
+	t.Run("Reports request counts and latency per method and route", func(t *testing.T) {
+		metrics.Reset()
 
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.True(t, response.Success)
-		assert.NotNil(t, response.Data)
-	})
+		metrics.RecordRequest(http.MethodGet, "/widgets", 200, 5*time.Millisecond)
+		metrics.RecordRequest(http.MethodGet, "/widgets", 201, 5*time.Millisecond)
+		metrics.RecordRequest(http.MethodGet, "/widgets", 404, 5*time.Millisecond)
+		metrics.RecordRequest(http.MethodPost, "/widgets", 500, 5*time.Millisecond)
 
-	t.Run("Metrics include all required fields", func(t *testing.T) {
 		router := setupHealthTestRouter()
 		router.GET("/metrics", controller.Metrics)
 
@@ -127,44 +180,93 @@ func TestHealthController_Metrics(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		var response struct {
-			Data dto.MetricsResponse `json:"data"`
-		}
-
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.GreaterOrEqual(t, response.Data.TotalRequests, int64(0))
-		assert.GreaterOrEqual(t, response.Data.SuccessRequests, int64(0))
-		assert.GreaterOrEqual(t, response.Data.ErrorRequests, int64(0))
-		assert.GreaterOrEqual(t, response.Data.UptimeSeconds, int64(0))
-		assert.NotZero(t, response.Data.Timestamp)
+		body := w.Body.String()
+		assert.Contains(t, body, `http_requests_total{method="GET",route="/widgets",status="200"} 1`)
+		assert.Contains(t, body, `http_requests_total{method="GET",route="/widgets",status="201"} 1`)
+		assert.Contains(t, body, `http_requests_total{method="GET",route="/widgets",status="404"} 1`)
+		assert.Contains(t, body, `http_requests_total{method="POST",route="/widgets",status="500"} 1`)
+		assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/widgets"} 3`)
 	})
+}
 
-	t.Run("Metrics counter increments correctly", func(t *testing.T) {
-		metrics.Reset()
+// TestHealthController_MetricsJSON tests the backward-compatible JSON
+// metrics endpoint.
+func TestHealthController_MetricsJSON(t *testing.T) {
+	metrics.Reset()
+	metrics.Init()
 
-		// Simulate some requests
-		metrics.RecordRequest(200)
-		metrics.RecordRequest(201)
-		metrics.RecordRequest(404)
-		metrics.RecordRequest(500)
+	metrics.RecordRequest(http.MethodGet, "/widgets", 200, 5*time.Millisecond)
+	metrics.RecordRequest(http.MethodGet, "/widgets", 404, 5*time.Millisecond)
+
+	service := services.NewHealthService()
+	controller := controllers.NewHealthController(service)
+
+	router := setupHealthTestRouter()
+	router.GET("/metrics/json", controller.MetricsJSON)
+
+	req, _ := http.NewRequest(http.MethodGet, "/metrics/json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.MetricsResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.EqualValues(t, 2, response.Data.TotalRequests)
+	assert.EqualValues(t, 1, response.Data.SuccessRequests)
+	assert.EqualValues(t, 1, response.Data.ErrorRequests)
+}
+
+// TestRequireMetricsToken tests the optional bearer-token gate in front of
+// the metrics endpoint.
+func TestRequireMetricsToken(t *testing.T) {
+	metrics.Init()
+	service := services.NewHealthService()
+	controller := controllers.NewHealthController(service)
+
+	t.Run("Allows unauthenticated access when no token configured", func(t *testing.T) {
+		viper.Set("METRICS_TOKEN", "")
+		defer viper.Set("METRICS_TOKEN", nil)
 
 		router := setupHealthTestRouter()
-		router.GET("/metrics", controller.Metrics)
+		router.GET("/metrics", middlewares.RequireMetricsToken(), controller.Metrics)
 
 		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		var response struct {
-			Data dto.MetricsResponse `json:"data"`
-		}
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.Equal(t, int64(4), response.Data.TotalRequests)
-		assert.Equal(t, int64(2), response.Data.SuccessRequests) // 200, 201
-		assert.Equal(t, int64(2), response.Data.ErrorRequests)   // 404, 500
+	t.Run("Rejects requests missing the bearer token", func(t *testing.T) {
+		viper.Set("METRICS_TOKEN", "s3cret")
+		defer viper.Set("METRICS_TOKEN", nil)
+
+		router := setupHealthTestRouter()
+		router.GET("/metrics", middlewares.RequireMetricsToken(), controller.Metrics)
+
+		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Allows requests with the correct bearer token", func(t *testing.T) {
+		viper.Set("METRICS_TOKEN", "s3cret")
+		defer viper.Set("METRICS_TOKEN", nil)
+
+		router := setupHealthTestRouter()
+		router.GET("/metrics", middlewares.RequireMetricsToken(), controller.Metrics)
+
+		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
 
@@ -0,0 +1,68 @@
+package i18n_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLocales(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{
+		"validation.required": "{field} is required"
+	}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id.json"), []byte(`{
+		"validation.required": "{field} wajib diisi"
+	}`), 0o644))
+
+	return dir
+}
+
+func TestLoadLocalesAndTranslate(t *testing.T) {
+	bundle, err := i18n.LoadLocales(writeLocales(t))
+	require.NoError(t, err)
+
+	tag := bundle.Match("id")
+	text, ok := bundle.Translate(tag, "validation.required", map[string]string{"field": "Email"})
+	require.True(t, ok)
+	assert.Equal(t, "Email wajib diisi", text)
+}
+
+func TestTranslateFallsBackToDefaultLocale(t *testing.T) {
+	bundle, err := i18n.LoadLocales(writeLocales(t))
+	require.NoError(t, err)
+
+	// "fr" has no bundle at all, so Match falls back to the first-loaded
+	// (default) locale.
+	tag := bundle.Match("fr")
+	text, ok := bundle.Translate(tag, "validation.required", map[string]string{"field": "Email"})
+	require.True(t, ok)
+	assert.Equal(t, "Email is required", text)
+}
+
+func TestTranslateMissingKey(t *testing.T) {
+	bundle, err := i18n.LoadLocales(writeLocales(t))
+	require.NoError(t, err)
+
+	_, ok := bundle.Translate(bundle.Match("en"), "validation.does_not_exist", nil)
+	assert.False(t, ok)
+}
+
+func TestLoadLocalesRejectsEmptyDir(t *testing.T) {
+	_, err := i18n.LoadLocales(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLoadLocalesRejectsInvalidLanguageTag(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "not-a-lang-tag!!.json"), []byte(`{}`), 0o644))
+
+	_, err := i18n.LoadLocales(dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext_NoFieldsAttached(t *testing.T) {
+	entry := logger.FromContext(context.Background())
+	assert.Empty(t, entry.Data)
+}
+
+func TestWithContext_AttachesFields(t *testing.T) {
+	ctx := logger.WithContext(context.Background(), logger.Fields{"request_id": "abc123"})
+
+	entry := logger.FromContext(ctx)
+	assert.Equal(t, "abc123", entry.Data["request_id"])
+}
+
+func TestWithContext_MergesRatherThanReplaces(t *testing.T) {
+	ctx := logger.WithContext(context.Background(), logger.Fields{"request_id": "abc123"})
+	ctx = logger.WithContext(ctx, logger.Fields{"user_id": "u1"})
+
+	entry := logger.FromContext(ctx)
+	assert.Equal(t, "abc123", entry.Data["request_id"])
+	assert.Equal(t, "u1", entry.Data["user_id"])
+}
+
+func TestWithContext_LaterFieldsOverrideEarlierOnes(t *testing.T) {
+	ctx := logger.WithContext(context.Background(), logger.Fields{"request_id": "first"})
+	ctx = logger.WithContext(ctx, logger.Fields{"request_id": "second"})
+
+	entry := logger.FromContext(ctx)
+	assert.Equal(t, "second", entry.Data["request_id"])
+}
+
+func TestCtx_IsFromContext(t *testing.T) {
+	ctx := logger.WithContext(context.Background(), logger.Fields{"trace_id": "t1"})
+	assert.Equal(t, logger.FromContext(ctx).Data, logger.Ctx(ctx).Data)
+}
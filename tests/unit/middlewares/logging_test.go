@@ -0,0 +1,70 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequestLoggingMiddleware tests the request-logging middleware
+func TestRequestLoggingMiddleware(t *testing.T) {
+	t.Run("Attaches request_id and route fields to the request context", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(middlewares.RequestIDMiddleware())
+		router.Use(middlewares.RequestLoggingMiddleware())
+
+		var fields map[string]interface{}
+		router.GET("/test", func(c *gin.Context) {
+			entry := logger.Ctx(c.Request.Context())
+			fields = entry.Data
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.NotEmpty(t, fields["request_id"])
+		assert.Equal(t, http.MethodGet, fields["method"])
+		assert.Equal(t, "/test", fields["path"])
+	})
+
+	t.Run("Does not affect request handling", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(middlewares.RequestIDMiddleware())
+		router.Use(middlewares.RequestLoggingMiddleware())
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "ok"})
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "ok")
+	})
+}
+
+// BenchmarkRequestLoggingMiddleware benchmarks the request-logging middleware
+func BenchmarkRequestLoggingMiddleware(b *testing.B) {
+	router := setupTestRouter()
+	router.Use(middlewares.RequestIDMiddleware())
+	router.Use(middlewares.RequestLoggingMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+}
@@ -1,8 +1,10 @@
 package middlewares_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
@@ -11,6 +13,21 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// requestsTotalFor extracts the http_requests_total count recorded for a
+// given method, route and status code from the Prometheus exposition text,
+// returning 0 if no such series was recorded.
+func requestsTotalFor(method, route string, status int) int64 {
+	prefix := fmt.Sprintf(`http_requests_total{method=%q,route=%q,status=%q} `, method, route, fmt.Sprint(status))
+	for _, line := range strings.Split(metrics.Expose(), "\n") {
+		if strings.HasPrefix(line, prefix) {
+			var count int64
+			fmt.Sscanf(strings.TrimPrefix(line, prefix), "%d", &count)
+			return count
+		}
+	}
+	return 0
+}
+
 // TestMetricsMiddleware tests the metrics collection middleware
 func TestMetricsMiddleware(t *testing.T) {
 	t.Run("Records successful request (200)", func(t *testing.T) {
@@ -26,9 +43,7 @@ func TestMetricsMiddleware(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, int64(1), metrics.GetTotalRequests())
-		assert.Equal(t, int64(1), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(0), metrics.GetErrorRequests())
+		assert.EqualValues(t, 1, requestsTotalFor(http.MethodGet, "/test", http.StatusOK))
 	})
 
 	t.Run("Records client error (400)", func(t *testing.T) {
@@ -44,9 +59,7 @@ func TestMetricsMiddleware(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, int64(1), metrics.GetTotalRequests())
-		assert.Equal(t, int64(0), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(1), metrics.GetErrorRequests())
+		assert.EqualValues(t, 1, requestsTotalFor(http.MethodGet, "/test", http.StatusBadRequest))
 	})
 
 	t.Run("Records server error (500)", func(t *testing.T) {
@@ -62,9 +75,7 @@ func TestMetricsMiddleware(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, int64(1), metrics.GetTotalRequests())
-		assert.Equal(t, int64(0), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(1), metrics.GetErrorRequests())
+		assert.EqualValues(t, 1, requestsTotalFor(http.MethodGet, "/test", http.StatusInternalServerError))
 	})
 
 	t.Run("Records multiple requests correctly", func(t *testing.T) {
@@ -93,73 +104,26 @@ func TestMetricsMiddleware(t *testing.T) {
 			router.ServeHTTP(w, req)
 		}
 
-		assert.Equal(t, int64(5), metrics.GetTotalRequests())
-		assert.Equal(t, int64(3), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(2), metrics.GetErrorRequests())
-	})
-
-	t.Run("Records 2xx status codes as success", func(t *testing.T) {
-		metrics.Reset()
-
-		statusCodes := []int{200, 201, 202, 204}
-		for _, code := range statusCodes {
-			router := setupTestRouter()
-			router.Use(middlewares.MetricsMiddleware())
-
-			currentCode := code // Capture current value
-			router.GET("/test", func(c *gin.Context) {
-				c.Status(currentCode)
-			})
-
-			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
-			w := httptest.NewRecorder()
-			router.ServeHTTP(w, req)
-		}
-
-		assert.Equal(t, int64(4), metrics.GetTotalRequests())
-		assert.Equal(t, int64(4), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(0), metrics.GetErrorRequests())
+		assert.EqualValues(t, 3, requestsTotalFor(http.MethodGet, "/success", http.StatusOK))
+		assert.EqualValues(t, 2, requestsTotalFor(http.MethodGet, "/error", http.StatusNotFound))
 	})
 
-	t.Run("Records 3xx status codes as success", func(t *testing.T) {
+	t.Run("Keys requests by matched route, not raw path", func(t *testing.T) {
 		metrics.Reset()
 
 		router := setupTestRouter()
 		router.Use(middlewares.MetricsMiddleware())
-		router.GET("/test", func(c *gin.Context) {
-			c.Redirect(http.StatusMovedPermanently, "/new-location")
+		router.GET("/users/:id", func(c *gin.Context) {
+			c.Status(http.StatusOK)
 		})
 
-		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
-		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
-
-		assert.Equal(t, int64(1), metrics.GetTotalRequests())
-		assert.Equal(t, int64(1), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(0), metrics.GetErrorRequests())
-	})
-
-	t.Run("Records 4xx and 5xx as errors", func(t *testing.T) {
-		metrics.Reset()
-
-		errorCodes := []int{400, 401, 403, 404, 409, 429, 500, 502, 503}
-		for _, code := range errorCodes {
-			router := setupTestRouter()
-			router.Use(middlewares.MetricsMiddleware())
-
-			currentCode := code // Capture current value
-			router.GET("/test", func(c *gin.Context) {
-				c.Status(currentCode)
-			})
-
-			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		for _, id := range []string{"1", "2", "3"} {
+			req, _ := http.NewRequest(http.MethodGet, "/users/"+id, nil)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 		}
 
-		assert.Equal(t, int64(9), metrics.GetTotalRequests())
-		assert.Equal(t, int64(0), metrics.GetSuccessRequests())
-		assert.Equal(t, int64(9), metrics.GetErrorRequests())
+		assert.EqualValues(t, 3, requestsTotalFor(http.MethodGet, "/users/:id", http.StatusOK))
 	})
 
 	t.Run("Does not affect request handling", func(t *testing.T) {
@@ -180,7 +144,7 @@ func TestMetricsMiddleware(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "success")
 
 		// Verify metrics recorded
-		assert.Equal(t, int64(1), metrics.GetTotalRequests())
+		assert.EqualValues(t, 1, requestsTotalFor(http.MethodGet, "/test", http.StatusOK))
 	})
 }
 
@@ -211,11 +175,28 @@ func TestMetricsThreadSafety(t *testing.T) {
 	}
 
 	// All 100 requests should be counted
-	assert.Equal(t, int64(100), metrics.GetTotalRequests(), "All concurrent requests should be counted")
-	assert.Equal(t, int64(100), metrics.GetSuccessRequests())
+	assert.EqualValues(t, 100, requestsTotalFor(http.MethodGet, "/test", http.StatusOK), "All concurrent requests should be counted")
 }
 
-// BenchmarkMetricsMiddleware benchmarks the metrics middleware
+// TestMetricsMiddlewareSkipsMetricsRoute verifies that scraping /metrics
+// doesn't instrument itself as a recurring request.
+func TestMetricsMiddlewareSkipsMetricsRoute(t *testing.T) {
+	metrics.Reset()
+
+	router := setupTestRouter()
+	router.Use(middlewares.MetricsMiddleware())
+	router.GET("/metrics", func(c *gin.Context) {
+		c.String(http.StatusOK, metrics.Expose())
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.EqualValues(t, 0, requestsTotalFor(http.MethodGet, "/metrics", http.StatusOK))
+}
+
+// BenchmarkMetricsMiddleware benchmarks the metrics middleware.
 func BenchmarkMetricsMiddleware(b *testing.B) {
 	metrics.Reset()
 
@@ -233,3 +214,30 @@ func BenchmarkMetricsMiddleware(b *testing.B) {
 		router.ServeHTTP(w, req)
 	}
 }
+
+// BenchmarkMetricsMiddlewareManyRoutes benchmarks the histogram path under
+// many distinct method+route label combinations, demonstrating that moving
+// the latency histogram to a real prometheus.HistogramVec isn't a
+// regression versus the hand-rolled per-route histogram map it replaced.
+func BenchmarkMetricsMiddlewareManyRoutes(b *testing.B) {
+	metrics.Reset()
+
+	router := setupTestRouter()
+	router.Use(middlewares.MetricsMiddleware())
+	for i := 0; i < 20; i++ {
+		router.GET(fmt.Sprintf("/route-%d", i), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+	}
+
+	requests := make([]*http.Request, 20)
+	for i := range requests {
+		requests[i], _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/route-%d", i), nil)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, requests[i%len(requests)])
+	}
+}
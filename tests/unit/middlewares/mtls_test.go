@@ -0,0 +1,88 @@
+package middlewares_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// withPeerCert stamps req.TLS with a fake connection state carrying cert,
+// the way net/http would after a successful mTLS handshake, so the
+// middleware can be exercised without a real TLS listener.
+func withPeerCert(req *http.Request, cert *x509.Certificate) {
+	req.TLS = &tls.ConnectionState{}
+	if cert != nil {
+		req.TLS.PeerCertificates = []*x509.Certificate{cert}
+	}
+}
+
+func TestMTLSRequiredRejectsRequestWithoutCertificate(t *testing.T) {
+	router := setupTestRouter()
+	router.GET("/admin", middlewares.MTLSRequired(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestMTLSRequiredAllowsAnyCertWhenNoAllowlist(t *testing.T) {
+	router := setupTestRouter()
+	router.GET("/admin", middlewares.MTLSRequired(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+	withPeerCert(req, &x509.Certificate{Subject: pkix.Name{CommonName: "scraper-1"}})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMTLSRequiredEnforcesCommonNameAllowlist(t *testing.T) {
+	router := setupTestRouter()
+	router.GET("/admin", middlewares.MTLSRequired("scraper-1"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("rejects a certificate not on the allowlist", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+		withPeerCert(req, &x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("allows a certificate on the allowlist", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+		withPeerCert(req, &x509.Certificate{Subject: pkix.Name{CommonName: "scraper-1"}})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("allows a certificate matched by DNS SAN", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+		withPeerCert(req, &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "unknown"},
+			DNSNames: []string{"scraper-1"},
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,49 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByUserID(t *testing.T) {
+	t.Run("keys on the authenticated user ID", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+		c.Set("user_id", uint(42))
+
+		assert.Equal(t, "user:42", middlewares.ByUserID(c))
+	})
+
+	t.Run("falls back to IP when unauthenticated", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+		assert.Equal(t, middlewares.ByIP(c), middlewares.ByUserID(c))
+	})
+}
+
+func TestComposite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users", func(ctx *gin.Context) {
+		ctx.Set("user_id", uint(7))
+		key := middlewares.Composite(middlewares.ByRoute, middlewares.ByUserID)(ctx)
+		assert.Equal(t, "route:/users+user:7", key)
+		ctx.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,130 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/middlewares"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimitMiddlewareWithPolicy tests the policy-driven rate limiting
+// middleware backed by an in-memory RateLimitStore.
+func TestRateLimitMiddlewareWithPolicy(t *testing.T) {
+	metrics.Init()
+
+	t.Run("Allows requests under limit and reports limit headers", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(middlewares.RateLimitMiddlewareWithPolicy(middlewares.RateLimitPolicy{
+			Key:   middlewares.ByIP,
+			RPS:   10,
+			Burst: 10,
+			Store: middlewares.NewMemoryRateLimitStore(),
+		}))
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "ok"})
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+		assert.NotEmpty(t, w.Header().Get("RateLimit-Remaining"))
+		assert.NotEmpty(t, w.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("Blocks requests exceeding burst and sets Retry-After", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(middlewares.RateLimitMiddlewareWithPolicy(middlewares.RateLimitPolicy{
+			Key:   middlewares.ByIP,
+			RPS:   1,
+			Burst: 1,
+			Store: middlewares.NewMemoryRateLimitStore(),
+		}))
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "ok"})
+		})
+
+		var blocked bool
+		for i := 0; i < 5; i++ {
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code == http.StatusTooManyRequests {
+				blocked = true
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+				break
+			}
+		}
+
+		assert.True(t, blocked, "expected at least one request to be rate limited")
+	})
+
+	t.Run("Applies independent limits per policy key", func(t *testing.T) {
+		store := middlewares.NewMemoryRateLimitStore()
+		router := setupTestRouter()
+		router.Use(middlewares.RateLimitMiddlewareWithPolicy(middlewares.RateLimitPolicy{
+			Key:   middlewares.ByRoute,
+			RPS:   1,
+			Burst: 1,
+			Store: store,
+		}))
+		router.GET("/a", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.GET("/b", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		reqA, _ := http.NewRequest(http.MethodGet, "/a", nil)
+		wA := httptest.NewRecorder()
+		router.ServeHTTP(wA, reqA)
+		assert.Equal(t, http.StatusOK, wA.Code)
+
+		reqB, _ := http.NewRequest(http.MethodGet, "/b", nil)
+		wB := httptest.NewRecorder()
+		router.ServeHTTP(wB, reqB)
+		assert.Equal(t, http.StatusOK, wB.Code, "route /b has its own budget, independent of /a")
+	})
+}
+
+// TestMemoryRateLimitStore tests the in-memory RateLimitStore directly.
+func TestMemoryRateLimitStore(t *testing.T) {
+	store := middlewares.NewMemoryRateLimitStore()
+
+	allowed, remaining, _, err := store.Allow(context.Background(), "k1", 1, 1)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	assert.GreaterOrEqual(t, remaining, 0)
+
+	allowed, _, retryAfter, err := store.Allow(context.Background(), "k1", 1, 1)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Greater(t, retryAfter.Nanoseconds(), int64(0))
+}
+
+// BenchmarkRateLimitMiddlewareWithPolicy benchmarks the policy-driven
+// middleware backed by the in-memory store.
+func BenchmarkRateLimitMiddlewareWithPolicy(b *testing.B) {
+	router := setupTestRouter()
+	router.Use(middlewares.RateLimitMiddlewareWithPolicy(middlewares.RateLimitPolicy{
+		Key:   middlewares.ByIP,
+		RPS:   1000,
+		Burst: 2000,
+		Store: middlewares.NewMemoryRateLimitStore(),
+	}))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+}
@@ -0,0 +1,92 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/password"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBcryptHashUpgradesToArgon2id mirrors AuthService.Login's
+// rehash-on-login behavior: a hash produced under an older bcrypt
+// configuration is flagged by NeedsRehash once the app is reconfigured for
+// Argon2id, and re-hashing it produces a hash that verifies against the
+// same password and no longer needs an upgrade.
+func TestBcryptHashUpgradesToArgon2id(t *testing.T) {
+	bcryptHasher, err := password.New(password.Config{Algo: password.AlgoBcrypt, BcryptCost: 4})
+	assert.NoError(t, err)
+
+	plainPassword := "SecurePass123!"
+	hash, err := bcryptHasher.Hash(plainPassword)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$2"))
+
+	argon2Hasher, err := password.New(password.Config{Algo: password.AlgoArgon2id})
+	assert.NoError(t, err)
+
+	// The bcrypt hash still verifies against an Argon2id-configured Hasher...
+	ok, err := argon2Hasher.Verify(hash, plainPassword)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// ...but is flagged for an upgrade, since the deployment now wants Argon2id.
+	assert.True(t, argon2Hasher.NeedsRehash(hash))
+
+	upgraded, err := argon2Hasher.Hash(plainPassword)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(upgraded, "$argon2id$"))
+
+	ok, err = argon2Hasher.Verify(upgraded, plainPassword)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, argon2Hasher.NeedsRehash(upgraded))
+}
+
+// TestArgon2idNeedsRehashOnParameterChange ensures a deployment that raises
+// its Argon2id cost parameters flags existing hashes for an upgrade too,
+// not just hashes from a different algorithm.
+func TestArgon2idNeedsRehashOnParameterChange(t *testing.T) {
+	weak, err := password.New(password.Config{Algo: password.AlgoArgon2id, Argon2MemoryKiB: 8 * 1024, Argon2Iterations: 1, Argon2Parallelism: 1})
+	assert.NoError(t, err)
+
+	hash, err := weak.Hash("SecurePass123!")
+	assert.NoError(t, err)
+
+	strong, err := password.New(password.Config{Algo: password.AlgoArgon2id, Argon2MemoryKiB: 64 * 1024, Argon2Iterations: 3, Argon2Parallelism: 2})
+	assert.NoError(t, err)
+
+	assert.True(t, strong.NeedsRehash(hash))
+	assert.False(t, weak.NeedsRehash(hash))
+}
+
+// TestVerifyRejectsWrongPassword ensures Verify distinguishes a mismatched
+// password from a malformed hash.
+func TestVerifyRejectsWrongPassword(t *testing.T) {
+	hasher, err := password.New(password.Config{Algo: password.AlgoArgon2id})
+	assert.NoError(t, err)
+
+	hash, err := hasher.Hash("SecurePass123!")
+	assert.NoError(t, err)
+
+	ok, err := hasher.Verify(hash, "WrongPassword!")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestPepperChangesHash ensures a configured pepper actually participates in
+// hashing: the same password under two different peppers must not verify
+// against each other's hash.
+func TestPepperChangesHash(t *testing.T) {
+	hasherA, err := password.New(password.Config{Algo: password.AlgoArgon2id, Pepper: "pepper-a"})
+	assert.NoError(t, err)
+	hasherB, err := password.New(password.Config{Algo: password.AlgoArgon2id, Pepper: "pepper-b"})
+	assert.NoError(t, err)
+
+	hash, err := hasherA.Hash("SecurePass123!")
+	assert.NoError(t, err)
+
+	ok, err := hasherB.Verify(hash, "SecurePass123!")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
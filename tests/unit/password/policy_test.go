@@ -0,0 +1,39 @@
+package password_test
+
+import (
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/password"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyConfigValidate(t *testing.T) {
+	cfg := password.PolicyConfig{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	t.Run("accepts a password satisfying every rule", func(t *testing.T) {
+		assert.NoError(t, cfg.Validate("Sup3r$ecret!"))
+	})
+
+	t.Run("rejects a password shorter than MinLength", func(t *testing.T) {
+		assert.Error(t, cfg.Validate("Sh0rt!"))
+	})
+
+	t.Run("rejects a password missing a required character class", func(t *testing.T) {
+		assert.Error(t, cfg.Validate("alllowercase1!"))
+	})
+
+	t.Run("rejects a blocklisted common password regardless of character classes", func(t *testing.T) {
+		weak := password.PolicyConfig{MinLength: 8}
+		assert.Error(t, weak.Validate("password1!"))
+	})
+
+	t.Run("DefaultPolicyConfig requires no character classes", func(t *testing.T) {
+		assert.NoError(t, password.DefaultPolicyConfig().Validate("just-long-enough"))
+	})
+}
@@ -0,0 +1,87 @@
+package repositories_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupRefreshTokenTestDB points database.DB at a fresh in-memory SQLite
+// database migrated for models.RefreshToken, so repository tests can
+// exercise real SQL (including RowsAffected) without a Postgres instance.
+func setupRefreshTokenTestDB(t *testing.T) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.RefreshToken{}))
+
+	// SQLite serializes writers at the connection level; a single
+	// connection turns would-be "database is locked" errors under
+	// concurrent writers into queuing instead, which is what we want here
+	// since it's the UPDATE's WHERE clause being tested, not SQLite's own
+	// write concurrency.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	database.DB = db
+}
+
+// TestRevokeRefreshToken_SingleUse verifies that RevokeRefreshToken only
+// ever rotates a token once: of ten concurrent callers racing to rotate the
+// same still-valid token, exactly one observes success.
+func TestRevokeRefreshToken_SingleUse(t *testing.T) {
+	setupRefreshTokenTestDB(t)
+
+	parent := &models.RefreshToken{
+		UserID:    1,
+		TokenHash: "parent-hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, repositories.CreateRefreshToken(parent))
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	results := make([]bool, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			replacement := &models.RefreshToken{
+				UserID:    1,
+				TokenHash: fmt.Sprintf("replacement-hash-%d", i),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			require.NoError(t, repositories.CreateRefreshToken(replacement))
+
+			revoked, err := repositories.RevokeRefreshToken(&models.RefreshToken{ID: parent.ID}, &replacement.ID)
+			assert.NoError(t, err)
+			results[i] = revoked
+		}(i)
+	}
+	wg.Wait()
+
+	var revokedCount int
+	for _, r := range results {
+		if r {
+			revokedCount++
+		}
+	}
+	assert.Equal(t, 1, revokedCount, "exactly one concurrent rotation should succeed")
+
+	// A subsequent, non-concurrent attempt against the now-revoked token
+	// must also be refused.
+	revoked, err := repositories.RevokeRefreshToken(&models.RefreshToken{ID: parent.ID}, nil)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
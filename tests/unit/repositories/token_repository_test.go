@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/adapters/database"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/models"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTokenTestDB points database.DB at a fresh in-memory SQLite database
+// migrated for models.Token, so repository tests can exercise real SQL
+// (including RowsAffected) without a Postgres instance.
+func setupTokenTestDB(t *testing.T) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Token{}))
+
+	// SQLite serializes writers at the connection level; a single
+	// connection turns would-be "database is locked" errors under
+	// concurrent writers into queuing instead, which is what we want here
+	// since it's the UPDATE's WHERE clause being tested, not SQLite's own
+	// write concurrency.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	database.DB = db
+}
+
+// TestMarkTokenUsed_SingleUse verifies that MarkTokenUsed only ever redeems
+// a token once: of two concurrent callers racing on the same still-valid
+// token, exactly one observes success.
+func TestMarkTokenUsed_SingleUse(t *testing.T) {
+	setupTokenTestDB(t)
+
+	tokenHash := repositories.HashToken("a-reset-token")
+	require.NoError(t, repositories.CreateToken(&models.Token{
+		Token:     tokenHash,
+		Type:      models.TokenTypePasswordReset,
+		UserID:    1,
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}))
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	results := make([]bool, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			redeemed, err := repositories.MarkTokenUsed(tokenHash)
+			assert.NoError(t, err)
+			results[i] = redeemed
+		}(i)
+	}
+	wg.Wait()
+
+	var redeemedCount int
+	for _, r := range results {
+		if r {
+			redeemedCount++
+		}
+	}
+	assert.Equal(t, 1, redeemedCount, "exactly one concurrent redemption should succeed")
+
+	// A subsequent, non-concurrent attempt against the now-used token must
+	// also be refused.
+	redeemed, err := repositories.MarkTokenUsed(tokenHash)
+	assert.NoError(t, err)
+	assert.False(t, redeemed)
+}
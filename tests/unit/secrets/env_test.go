@@ -0,0 +1,47 @@
+package secrets_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/secrets"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvProviderReadsFromViper(t *testing.T) {
+	viper.Set("TEST_SECRET", "from-viper")
+	defer viper.Set("TEST_SECRET", nil)
+
+	value, err := secrets.NewEnvProvider().GetSecret(context.Background(), "TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "from-viper", string(value))
+}
+
+func TestEnvProviderReadsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	viper.Set("TEST_SECRET_FILE", path)
+	defer viper.Set("TEST_SECRET_FILE", nil)
+
+	value, err := secrets.NewEnvProvider().GetSecret(context.Background(), "TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", string(value))
+}
+
+func TestEnvProviderMissingSecret(t *testing.T) {
+	_, err := secrets.NewEnvProvider().GetSecret(context.Background(), "TEST_SECRET_DOES_NOT_EXIST")
+	assert.Error(t, err)
+}
+
+func TestEnvProviderWatchNeverFires(t *testing.T) {
+	select {
+	case <-secrets.NewEnvProvider().Watch("TEST_SECRET"):
+		t.Fatal("expected the env provider's Watch channel to never fire")
+	default:
+	}
+}
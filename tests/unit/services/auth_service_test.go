@@ -191,7 +191,7 @@ func TestRefreshToken(t *testing.T) {
 				RefreshToken: tt.refreshToken,
 			}
 
-			response, err := service.RefreshToken(req)
+			response, err := service.RefreshToken(req, services.RequestContext{})
 
 			if tt.expectError != nil {
 				assert.Error(t, err)
@@ -214,34 +214,26 @@ func TestForgotPassword(t *testing.T) {
 	// 1. Mock repository.GetUserByEmail
 	// 2. Mock repository.UpdateUser
 	// 3. Test token generation and expiry
-	// 4. Test email not found scenario (security - don't reveal user existence)
+	// 4. Test that a non-existent email still returns nil (no enumeration oracle)
 	t.Skip("Skipping: Requires mocked repository and test database setup")
 
 	service := services.NewAuthService()
 
 	tests := []struct {
-		name        string
-		email       string
-		expectError error
-		expectToken bool
+		name  string
+		email string
 	}{
 		{
-			name:        "Valid email - user exists",
-			email:       "user@example.com",
-			expectError: nil,
-			expectToken: true,
+			name:  "Valid email - user exists",
+			email: "user@example.com",
 		},
 		{
-			name:        "Email not found",
-			email:       "nonexistent@example.com",
-			expectError: services.ErrUserNotFound,
-			expectToken: false,
+			name:  "Email not found",
+			email: "nonexistent@example.com",
 		},
 		{
-			name:        "Invalid email format",
-			email:       "invalid-email",
-			expectError: nil, // Validation happens in controller
-			expectToken: false,
+			name:  "Invalid email format",
+			email: "invalid-email", // Validation happens in controller
 		},
 	}
 
@@ -251,19 +243,10 @@ func TestForgotPassword(t *testing.T) {
 				Email: tt.email,
 			}
 
-			token, err := service.ForgotPassword(req)
-
-			if tt.expectError != nil {
-				assert.Error(t, err)
-				assert.Empty(t, token)
-			} else {
-				assert.NoError(t, err)
-				if tt.expectToken {
-					assert.NotEmpty(t, token)
-					// Token should be 64 hex characters
-					assert.Len(t, token, 64)
-				}
-			}
+			// ForgotPassword never reports whether the email exists, so it
+			// should return nil for every case above.
+			err := service.ForgotPassword(req)
+			assert.NoError(t, err)
 		})
 	}
 }
@@ -272,11 +255,14 @@ func TestForgotPassword(t *testing.T) {
 func TestResetPassword(t *testing.T) {
 	// NOTE: This test requires database mocking and test setup
 	// In production, you should:
-	// 1. Mock repository.GetUserByPasswordResetToken
+	// 1. Mock repository.GetByToken
 	// 2. Mock repository.UpdateUser
 	// 3. Test token expiry validation
 	// 4. Test password hashing
 	// 5. Test token cleanup after successful reset
+	// The single-use/atomicity guarantee this depends on (repositories.MarkTokenUsed)
+	// is covered directly, against a real database, by
+	// tests/unit/repositories/token_repository_test.go.
 	t.Skip("Skipping: Requires mocked repository and test database setup")
 
 	service := services.NewAuthService()
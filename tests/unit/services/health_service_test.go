@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/internal/app/services"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChecker is a services.Checker controlled entirely by the test.
+type fakeChecker struct {
+	name     string
+	err      error
+	critical bool
+	calls    int
+}
+
+func (c *fakeChecker) Name() string           { return c.name }
+func (c *fakeChecker) Critical() bool         { return c.critical }
+func (c *fakeChecker) Timeout() time.Duration { return time.Second }
+func (c *fakeChecker) Check(ctx context.Context) error {
+	c.calls++
+	return c.err
+}
+
+func newHealthServiceWithoutCache(t *testing.T) *services.HealthService {
+	t.Helper()
+	viper.Set("HEALTH_CACHE_MS", -1)
+	t.Cleanup(func() { viper.Set("HEALTH_CACHE_MS", nil) })
+	return services.NewHealthServiceWithCheckers()
+}
+
+func TestHealthService_CheckLiveness(t *testing.T) {
+	s := services.NewHealthService()
+
+	resp := s.CheckLiveness()
+	assert.Equal(t, "healthy", resp.Status)
+	assert.Empty(t, resp.Checks)
+}
+
+func TestHealthService_CheckReadiness(t *testing.T) {
+	t.Run("Healthy when every critical checker passes", func(t *testing.T) {
+		s := newHealthServiceWithoutCache(t)
+		s.Register(&fakeChecker{name: "critical-ok", critical: true})
+		s.Register(&fakeChecker{name: "noncritical-fail", err: errors.New("down")})
+
+		resp := s.CheckReadiness(context.Background())
+		assert.Equal(t, "healthy", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["critical-ok"])
+		assert.Equal(t, "error", resp.Checks["noncritical-fail"])
+	})
+
+	t.Run("Unhealthy when a critical checker fails", func(t *testing.T) {
+		s := newHealthServiceWithoutCache(t)
+		s.Register(&fakeChecker{name: "critical-fail", err: errors.New("down"), critical: true})
+
+		resp := s.CheckReadiness(context.Background())
+		assert.Equal(t, "unhealthy", resp.Status)
+		assert.Equal(t, "error", resp.Checks["critical-fail"])
+	})
+
+	t.Run("Caches results within the configured TTL", func(t *testing.T) {
+		viper.Set("HEALTH_CACHE_MS", 60000)
+		defer viper.Set("HEALTH_CACHE_MS", nil)
+
+		s := services.NewHealthService()
+		checker := &fakeChecker{name: "counted", critical: true}
+		s.Register(checker)
+
+		s.CheckReadiness(context.Background())
+		s.CheckReadiness(context.Background())
+
+		require.Equal(t, 1, checker.calls, "expected the second call within the cache TTL to reuse the cached result")
+	})
+}
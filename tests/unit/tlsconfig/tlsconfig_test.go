@@ -0,0 +1,84 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/tlsconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert generates a throwaway self-signed EC cert/key pair
+// and writes both as PEM files under t.TempDir(), returning their paths.
+func writeSelfSignedCert(t *testing.T, cn string) (certPath, keyPath string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certPath, keyPath
+}
+
+func TestLoadServerTLSNone(t *testing.T) {
+	certPath, keyPath := writeSelfSignedCert(t, "server")
+
+	cfg, err := tlsconfig.LoadServerTLS(certPath, keyPath, "", "none")
+	require.NoError(t, err)
+	assert.Len(t, cfg.Certificates, 1)
+	assert.Nil(t, cfg.ClientCAs)
+}
+
+func TestLoadServerTLSVerifyRequiresClientCA(t *testing.T) {
+	certPath, keyPath := writeSelfSignedCert(t, "server")
+
+	_, err := tlsconfig.LoadServerTLS(certPath, keyPath, "", "verify")
+	assert.Error(t, err)
+}
+
+func TestLoadServerTLSVerifyLoadsClientCA(t *testing.T) {
+	certPath, keyPath := writeSelfSignedCert(t, "server")
+	caPath, _ := writeSelfSignedCert(t, "client-ca")
+
+	cfg, err := tlsconfig.LoadServerTLS(certPath, keyPath, caPath, "verify")
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.ClientCAs)
+}
+
+func TestLoadServerTLSUnknownAuthType(t *testing.T) {
+	certPath, keyPath := writeSelfSignedCert(t, "server")
+
+	_, err := tlsconfig.LoadServerTLS(certPath, keyPath, "", "bogus")
+	assert.Error(t, err)
+}
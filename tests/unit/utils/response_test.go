@@ -0,0 +1,137 @@
+package utils_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/apierr"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/i18n"
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"http.404": "Not Found", "greeting": "Hello"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id.json"), []byte(`{"http.404": "Tidak Ditemukan", "greeting": "Halo"}`), 0o644))
+
+	bundle, err := i18n.LoadLocales(dir)
+	require.NoError(t, err)
+	return bundle
+}
+
+func TestOkFallsBackToEnglishWithoutLocaleMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	utils.Ok(c, nil, "")
+
+	assert.Contains(t, w.Body.String(), "\"message\":\"OK\"")
+}
+
+func TestNotFoundTranslatesDefaultMessageViaLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	bundle := testBundle(t)
+	i18n.WithContext(c, bundle, bundle.Match("id"))
+
+	utils.NotFound(c, nil, "")
+
+	assert.Contains(t, w.Body.String(), "Tidak Ditemukan")
+}
+
+func TestHandleSuccessTranslatesMessageKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	bundle := testBundle(t)
+	i18n.WithContext(c, bundle, bundle.Match("id"))
+
+	utils.Ok(c, nil, "greeting")
+
+	assert.Contains(t, w.Body.String(), "Halo")
+}
+
+func TestHandleSuccessLeavesUnknownMessageVerbatim(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	bundle := testBundle(t)
+	i18n.WithContext(c, bundle, bundle.Match("id"))
+
+	utils.Ok(c, nil, "Profile retrieved successfully")
+
+	assert.Contains(t, w.Body.String(), "Profile retrieved successfully")
+}
+
+func TestHandleErrorsDefaultsToErrorResponseEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	utils.HandleErrors(c, http.StatusConflict, errors.New("email already exists"), "Email already exists")
+
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "\"message\":\"Email already exists\"")
+}
+
+func TestHandleErrorsRendersProblemJSONWhenRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/accounts", nil)
+	c.Request.Header.Set("Accept", "application/problem+json")
+	c.Set("request_id", "req-123")
+
+	problem := apierr.New("apierr_test.response_email_exists", http.StatusConflict, "Email Already Exists")
+	utils.HandleErrors(c, http.StatusConflict, problem, "")
+
+	assert.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "\"status\":409")
+	assert.Contains(t, body, "\"title\":\"Email Already Exists\"")
+	assert.Contains(t, body, "\"code\":\"apierr_test.response_email_exists\"")
+	assert.Contains(t, body, "\"instance\":\"/accounts\"")
+	assert.Contains(t, body, "\"trace_id\":\"req-123\"")
+}
+
+func TestRespondErrorUsesCatalogStatusForRegisteredProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	problem := apierr.New("apierr_test.response_invalid_credentials", http.StatusUnauthorized, "Invalid Credentials")
+	utils.RespondError(c, problem)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid Credentials")
+}
+
+func TestRespondErrorFallsBackToInternalServerErrorForUnregisteredError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	utils.RespondError(c, errors.New("boom"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
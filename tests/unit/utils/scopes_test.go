@@ -0,0 +1,27 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/bonarizki-dat/boilerplate-gin-dat/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes(t *testing.T) {
+	assert.Equal(t, []string{"admin", "recipes:write"}, utils.ParseScopes("admin, recipes:write"))
+	assert.Nil(t, utils.ParseScopes(""))
+	assert.Equal(t, []string{"admin"}, utils.ParseScopes("admin,,"))
+}
+
+func TestSerializeScopes(t *testing.T) {
+	assert.Equal(t, "admin,recipes:write", utils.SerializeScopes([]string{"admin", "recipes:write"}))
+	assert.Equal(t, "", utils.SerializeScopes(nil))
+}
+
+func TestIntersectScopes(t *testing.T) {
+	granted := []string{"admin", "recipes:write"}
+	required := []string{"recipes:write", "recipes:read"}
+
+	assert.Equal(t, []string{"recipes:write"}, utils.IntersectScopes(granted, required))
+	assert.Empty(t, utils.IntersectScopes(granted, []string{"billing:read"}))
+}